@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"go-api-template/pkg/bloom"
+	"go-api-template/pkg/log"
+	"io"
+	"os"
+	"strings"
+)
+
+var flagFormat = flag.String("format", "sha1", "format of the list: sha1 (hex SHA-1 per line, with an optional :count as in the Have I Been Pwned downloads) or plain (one password per line)")
+var flagRate = flag.Float64("rate", 0.001, "false positive rate of the filter")
+
+const usage = `usage: breach [flags] <list> <filter>
+
+Builds the bloom filter of compromised passwords auth.password.breachedList
+points to from a list of passwords or of their SHA-1 hashes.`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	logger := log.Setup()
+
+	args := flag.Args()
+	if len(args) != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *flagFormat != "sha1" && *flagFormat != "plain" {
+		logger.Fatal().Err(fmt.Errorf("unknown format %s", *flagFormat)).Msg("")
+	}
+
+	in, err := os.Open(args[0])
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+	defer in.Close()
+
+	// the filter is sized for the list, which takes a first pass to count
+	n, err := countLines(in)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	_, err = in.Seek(0, io.SeekStart)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	f := bloom.New(n, *flagRate)
+
+	added, err := fill(f, in)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	err = write(f, args[1])
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	logger.Info().Uint64("passwords", added).Str("filter", args[1]).Msg("breached password filter written")
+}
+
+func countLines(r io.Reader) (uint64, error) {
+	var n uint64
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		n++
+	}
+
+	return n, scanner.Err()
+}
+
+// fill adds the SHA-1 of every password of the list to f
+func fill(f *bloom.Filter, r io.Reader) (uint64, error) {
+	var added uint64
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+
+		var digest []byte
+		switch *flagFormat {
+		case "plain":
+			sum := sha1.Sum([]byte(text))
+			digest = sum[:]
+		default:
+			text = strings.TrimSpace(text)
+			if i := strings.IndexByte(text, ':'); i >= 0 {
+				text = text[:i]
+			}
+			if text == "" {
+				continue
+			}
+
+			var err error
+			digest, err = hex.DecodeString(text)
+			if err != nil || len(digest) != sha1.Size {
+				return added, fmt.Errorf("line %d: not a hex SHA-1", line)
+			}
+		}
+
+		f.Add(digest)
+		added++
+	}
+
+	return added, scanner.Err()
+}
+
+func write(f *bloom.Filter, path string) error {
+	tmp := path + ".tmp"
+
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(out)
+	_, err = f.WriteTo(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go-api-template/internal/config"
+	"go-api-template/internal/security"
+	"go-api-template/pkg/log"
+	"go-api-template/pkg/secret"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var flagConfig = flag.String("config", "./config/local.yml", "path to the config file")
+var flagKeys = flag.String("keys", "./config/keys", "path to the folder new keys are written to")
+
+const usage = `usage: keyring [flags] <command>
+
+commands:
+  list                    list the keys of the keyring
+  generate <alg> [id]     create a verify only key (RS256, ES256, EdDSA, HS256, ...)
+  promote <id>            make a key the active signing key
+  retire <id>             remove a key, tokens signed with it stop being valid
+
+Keys are rotated by generating a key, reloading every server (SIGHUP) so it is
+trusted everywhere, promoting it, reloading again and retiring the old key
+once the tokens it signed have expired.`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	logger := log.Setup()
+
+	cfg, err := config.New(*flagConfig)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	if cfg.Auth.Keyring == "" {
+		logger.Fatal().Err(errors.New("auth.keyring is not set in config")).Msg("")
+	}
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f := &security.KeyringFile{}
+	if fileExists(cfg.Auth.Keyring) {
+		f, err = security.ReadKeyringFile(cfg.Auth.Keyring)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("")
+		}
+	}
+
+	switch {
+	case args[0] == "list":
+		for _, k := range f.Keys {
+			state := "verify"
+			if k.ID == f.Active {
+				state = "active"
+			}
+			fmt.Printf("%s\t%s\t%s\n", k.ID, k.Algorithm, state)
+		}
+		return
+	case args[0] == "generate" && (len(args) == 2 || len(args) == 3):
+		err = generate(f, args[1:]...)
+	case args[0] == "promote" && len(args) == 2:
+		err = f.Promote(args[1])
+	case args[0] == "retire" && len(args) == 2:
+		err = f.Retire(args[1])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	err = f.Write(cfg.Auth.Keyring)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	logger.Info().Str("keyring", cfg.Auth.Keyring).Str("active", f.Active).Msg("keyring updated, send SIGHUP to the servers to reload it")
+}
+
+// generate adds a new key, it becomes the active key of an empty keyring
+func generate(f *security.KeyringFile, args ...string) error {
+	alg := args[0]
+	id := time.Now().UTC().Format("20060102150405")
+	if len(args) > 1 {
+		id = args[1]
+	}
+
+	k := security.KeyConfig{
+		ID:        id,
+		Algorithm: alg,
+	}
+
+	if alg == security.AlgorithmHS256 {
+		s, err := secret.Token(secret.DefaultSize)
+		if err != nil {
+			return err
+		}
+		k.Secret = s
+	} else {
+		k.File = filepath.Join(*flagKeys, id+".pem")
+	}
+
+	err := f.Add(k)
+	if err != nil {
+		return err
+	}
+
+	if k.File != "" {
+		data, err := security.GenerateKey(alg)
+		if err != nil {
+			return err
+		}
+
+		err = os.MkdirAll(*flagKeys, 0700)
+		if err != nil {
+			return err
+		}
+
+		err = ioutil.WriteFile(k.File, data, 0600)
+		if err != nil {
+			return err
+		}
+	}
+
+	if f.Active == "" {
+		return f.Promote(id)
+	}
+
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
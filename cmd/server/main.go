@@ -4,18 +4,31 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"go-api-template/internal/apikey"
+	"go-api-template/internal/audit"
 	"go-api-template/internal/config"
+	"go-api-template/internal/impersonation"
+	"go-api-template/internal/mfa"
+	"go-api-template/internal/oauth"
 	"go-api-template/internal/openapi"
+	"go-api-template/internal/privacy"
 	"go-api-template/internal/security"
+	"go-api-template/internal/throttle"
+	"go-api-template/internal/token"
 	"go-api-template/internal/transport"
 	"go-api-template/internal/user"
 	"go-api-template/pkg/db"
 	"go-api-template/pkg/log"
+	"go-api-template/pkg/mail"
+	"go-api-template/pkg/sms"
+	"go-api-template/pkg/storage"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/oklog/run"
 )
 
@@ -39,13 +52,87 @@ func main() {
 		logger.Fatal().Err(err).Msg("")
 	}
 
+	keys, err := security.OpenKeyring(cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	mailer, err := mail.New(logger.With().Str("layer", "mail").Logger(), cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	smsSender, err := sms.New(logger.With().Str("layer", "sms").Logger(), cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	breached, err := user.OpenBreachedPasswords(cfg.Auth.Password.BreachedList)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	tokenLookup, err := security.ParseTokenLookup(cfg.Auth.TokenLookup)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+	cookies := security.NewCookieAuth(tokenLookup, cfg)
+
+	// cookies are only sent cross origin to an origin allowed credentials
+	cors := middleware.DefaultCORSConfig
+	if cookies.Enabled() {
+		cors.AllowOrigins = []string{cfg.Server.AppURL}
+		cors.AllowCredentials = true
+	}
+
 	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
 
-	e := transport.NewEchoEngine(logger)
+	e := transport.NewEchoEngine(logger, cors)
+
+	blob, err := storage.New(logger.With().Str("layer", "storage").Logger(), cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("")
+	}
+
+	auditRepo := audit.NewRepository(logger.With().Str("svc", "audit").Str("layer", "repo").Logger(), db)
+	auditSvc := audit.NewService(logger.With().Str("svc", "audit").Str("layer", "service").Logger(), auditRepo)
 
 	userRepo := user.NewRepository(logger.With().Str("svc", "user").Str("layer", "repo").Logger(), db)
-	userSvc := user.NewService(logger.With().Str("svc", "user").Str("layer", "service").Logger(), userRepo)
-	userTransport := user.NewTransport(logger.With().Str("svc", "user").Str("layer", "transport").Logger(), userSvc, security.GenerateToken(cfg.Server.JWTKey))
+	userSvc := user.NewService(logger.With().Str("svc", "user").Str("layer", "service").Logger(), userRepo, mailer, smsSender, breached, auditSvc, blob, cfg)
+	tokenRepo := token.NewRepository(logger.With().Str("svc", "token").Str("layer", "repo").Logger(), db)
+	tokenSvc := token.NewService(logger.With().Str("svc", "token").Str("layer", "service").Logger(), tokenRepo, userSvc, security.GenerateToken(keys, cfg.Auth.AccessTokenTTL), cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL, cfg.Auth.RevocationCacheTTL)
+	oauthRepo := oauth.NewRepository(logger.With().Str("svc", "oauth").Str("layer", "repo").Logger(), db)
+	validator := security.NewValidator(keys, userSvc.FindByID, tokenSvc, oauth.NewGrantChecker(logger.With().Str("svc", "oauth").Str("layer", "service").Logger(), oauthRepo))
+	tokenTransport := token.NewTransport(logger.With().Str("svc", "token").Str("layer", "transport").Logger(), tokenSvc, cookies)
+
+	var throttleRepo throttle.Repository
+	switch cfg.Throttle.Store {
+	case "memory":
+		throttleRepo = throttle.NewMemoryRepository(cfg.Throttle.Window)
+	default:
+		throttleRepo = throttle.NewFallbackRepository(logger.With().Str("svc", "throttle").Str("layer", "repo").Logger(), throttle.NewRepository(logger.With().Str("svc", "throttle").Str("layer", "repo").Logger(), db), cfg.Throttle.Window)
+	}
+	throttleSvc := throttle.NewService(logger.With().Str("svc", "throttle").Str("layer", "service").Logger(), throttleRepo, cfg)
+
+	mfaRepo := mfa.NewRepository(logger.With().Str("svc", "mfa").Str("layer", "repo").Logger(), db)
+	mfaSvc := mfa.NewService(logger.With().Str("svc", "mfa").Str("layer", "service").Logger(), mfaRepo, cfg)
+	mfaTransport := mfa.NewTransport(logger.With().Str("svc", "mfa").Str("layer", "transport").Logger(), mfaSvc, userSvc, throttleSvc, security.GetUserIDFromEchoContext)
+
+	apiKeyRepo := apikey.NewRepository(logger.With().Str("svc", "apikey").Str("layer", "repo").Logger(), db)
+	apiKeySvc := apikey.NewService(logger.With().Str("svc", "apikey").Str("layer", "service").Logger(), apiKeyRepo, userSvc)
+	apiKeyTransport := apikey.NewTransport(logger.With().Str("svc", "apikey").Str("layer", "transport").Logger(), apiKeySvc, security.GetUserIDFromEchoContext)
+
+	oauthSvc := oauth.NewService(logger.With().Str("svc", "oauth").Str("layer", "service").Logger(), oauthRepo, userSvc, keys.Sign, validator.Validate, cfg)
+	oauthTransport := oauth.NewTransport(logger.With().Str("svc", "oauth").Str("layer", "transport").Logger(), oauthSvc, userSvc, security.GetClaimFromEchoContext, cfg.OAuth.ConsentURL)
+
+	impersonationSvc := impersonation.NewService(logger.With().Str("svc", "impersonation").Str("layer", "service").Logger(), userSvc, auditSvc, keys.Sign, tokenSvc.Revoke, cfg.Auth.ImpersonationTTL)
+	impersonationTransport := impersonation.NewTransport(logger.With().Str("svc", "impersonation").Str("layer", "transport").Logger(), impersonationSvc, security.GetClaimFromEchoContext)
+
+	privacyRepo := privacy.NewRepository(logger.With().Str("svc", "privacy").Str("layer", "repo").Logger(), db)
+	privacySvc := privacy.NewService(logger.With().Str("svc", "privacy").Str("layer", "service").Logger(), privacyRepo, userSvc, tokenSvc, auditSvc, cfg.Privacy.ExportTTL)
+	privacyTransport := privacy.NewTransport(logger.With().Str("svc", "privacy").Str("layer", "transport").Logger(), privacySvc, security.GetUserIDFromEchoContext)
+
+	userTransport := user.NewTransport(logger.With().Str("svc", "user").Str("layer", "transport").Logger(), userSvc, tokenSvc, throttleSvc, mfaSvc, cookies, security.GetUserIDFromEchoContext, cfg)
 
 	swagger, err := openapi.GetSwagger()
 	if err != nil {
@@ -53,11 +140,26 @@ func main() {
 	}
 	swagger.Servers = nil
 
+	e.GET("/.well-known/jwks.json", security.JWKSHandler(keys))
+	e.GET("/.well-known/openid-configuration", oauth.DiscoveryHandler(cfg.OAuth.Issuer, keys))
+
+	// the OAuth endpoints speak form encoding and redirects, not the api spec
+	e.GET("/oauth/authorize", oauthTransport.Authorize)
+	e.POST("/oauth/token", oauthTransport.Token)
+	e.POST("/oauth/introspect", oauthTransport.Introspect)
+
+	if cfg.Storage.Driver == "fs" {
+		e.Static("/media", cfg.Storage.Dir)
+	}
+
 	apiGroup := e.Group("/api")
 
-	apiGroup.Use(security.ValidationMiddleware(swagger, cfg.Server.JWTKey, userSvc.FindByID))
+	// bodies are read whole by the validation, only uploads are large
+	apiGroup.Use(middleware.BodyLimit(strconv.FormatInt(cfg.Avatar.MaxSize+1<<20, 10)))
 
-	openapi.RegisterHandlersWithBaseURL(apiGroup, transport.New(userTransport), "/api/v1")
+	apiGroup.Use(security.ValidationMiddleware(swagger, validator, tokenLookup, cookies, apiKeySvc))
+
+	openapi.RegisterHandlersWithBaseURL(apiGroup, transport.New(userTransport, tokenTransport, mfaTransport, apiKeyTransport, oauthTransport, impersonationTransport, privacyTransport), "/api/v1")
 
 	var g run.Group
 	{
@@ -79,6 +181,87 @@ func main() {
 			}
 		})
 	}
+	{
+		// reload the keyring on SIGHUP so keys can be rotated without a restart
+		var (
+			cancelReload = make(chan struct{})
+			c            = make(chan os.Signal, 1)
+		)
+		defer close(c)
+
+		g.Add(func() error {
+			signal.Notify(c, syscall.SIGHUP)
+			for {
+				select {
+				case <-c:
+					if err := keys.Reload(); err != nil {
+						logger.Error().Err(err).Msg("reload keyring")
+						continue
+					}
+					logger.Info().Str("msg", "keyring reloaded").Msg("server")
+				case <-cancelReload:
+					return nil
+				}
+			}
+		}, func(error) {
+			signal.Stop(c)
+			close(cancelReload)
+		})
+	}
+	{
+		// erase the accounts deleted longer than the retention ago
+		var (
+			cancelPurge = make(chan struct{})
+			ticker      = time.NewTicker(cfg.Accounts.PurgeInterval)
+		)
+
+		g.Add(func() error {
+			for {
+				select {
+				case <-ticker.C:
+					n, err := userSvc.Purge(context.Background())
+					if err != nil {
+						logger.Error().Err(err).Msg("purge users")
+					}
+					if n > 0 {
+						logger.Info().Int("users", n).Msg("purged users")
+					}
+				case <-cancelPurge:
+					return nil
+				}
+			}
+		}, func(error) {
+			ticker.Stop()
+			close(cancelPurge)
+		})
+	}
+	{
+		// assemble the data exports users asked for
+		var (
+			cancelExports = make(chan struct{})
+			ticker        = time.NewTicker(cfg.Privacy.ExportInterval)
+		)
+
+		g.Add(func() error {
+			for {
+				select {
+				case <-ticker.C:
+					n, err := privacySvc.ProcessExports(context.Background())
+					if err != nil {
+						logger.Error().Err(err).Msg("process exports")
+					}
+					if n > 0 {
+						logger.Info().Int("exports", n).Msg("processed exports")
+					}
+				case <-cancelExports:
+					return nil
+				}
+			}
+		}, func(error) {
+			ticker.Stop()
+			close(cancelExports)
+		})
+	}
 	{
 		// set-up our signal handler
 		var (
@@ -8,9 +8,11 @@ require (
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/getkin/kin-openapi v0.26.0
 	github.com/go-pg/pg/v10 v10.7.3
+	github.com/google/uuid v1.1.1
 	github.com/labstack/echo/v4 v4.1.11
 	github.com/oklog/run v1.1.0
 	github.com/robinjoseph08/go-pg-migrations/v3 v3.0.0
 	github.com/rs/zerolog v1.20.0
+	golang.org/x/crypto v0.0.0-20201117144127-c1f2f97bffc9
 	gopkg.in/yaml.v2 v2.3.0
 )
@@ -0,0 +1,51 @@
+package apikey
+
+import (
+	"context"
+	"time"
+)
+
+// APIKey lets a machine client act as its owner. The key is shown once, only
+// its prefix, to find it again, and a hash of its secret are stored.
+type APIKey struct {
+	tableName struct{} `pg:"api_keys,alias:api_keys"`
+
+	ID         int      `pg:",pk"`
+	UserID     int      `pg:",notnull"`
+	Name       string   `pg:",notnull"`
+	Prefix     string   `pg:",unique,notnull"`
+	SecretHash string   `pg:",notnull"`
+	Scopes     []string `pg:",array,notnull"`
+
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+
+	CreatedAt time.Time `pg:",notnull"`
+	UpdatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *APIKey) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+	o.UpdatedAt = o.CreatedAt
+
+	return c, nil
+}
+
+// BeforeUpdate Before update trigger
+func (o *APIKey) BeforeUpdate(c context.Context) (context.Context, error) {
+	o.UpdatedAt = time.Now()
+
+	return c, nil
+}
+
+// Expired reports whether the key can no longer be used
+func (o *APIKey) Expired(now time.Time) bool {
+	return o.ExpiresAt != nil && !o.ExpiresAt.After(now)
+}
+
+// Update is a partial update of a key, nil fields are left unchanged
+type Update struct {
+	Name   *string
+	Scopes *[]string
+}
@@ -0,0 +1,154 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/rs/zerolog"
+)
+
+// Repository is data provider
+type Repository interface {
+	Create(ctx context.Context, k *APIKey) (*APIKey, error)
+	FindByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	Find(ctx context.Context, userID int, id int) (*APIKey, error)
+	List(ctx context.Context, userID int) ([]APIKey, error)
+	Update(ctx context.Context, k *APIKey, columns ...string) error
+	Delete(ctx context.Context, userID int, id int) error
+	Touch(ctx context.Context, id int, at time.Time) error
+}
+
+var (
+	errRepoKeyNotFound      = errors.New("api key not found")
+	errRepoKeyAlreadyExists = errors.New("api key already exists")
+)
+
+// touchInterval is how stale last_used_at may get, so that a busy key does
+// not cost a write on every request
+const touchInterval = time.Minute
+
+type repo struct {
+	logger zerolog.Logger
+	db     *pg.DB
+}
+
+func (r repo) Create(ctx context.Context, k *APIKey) (*APIKey, error) {
+	_, err := r.db.ModelContext(ctx, k).Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		pgErr, ok := err.(pg.Error)
+		if ok && pgErr.IntegrityViolation() {
+			return nil, errRepoKeyAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	return k, nil
+}
+
+func (r repo) FindByPrefix(ctx context.Context, prefix string) (*APIKey, error) {
+	k := &APIKey{}
+
+	err := r.db.ModelContext(ctx, k).Where("prefix = ?", prefix).First()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, errRepoKeyNotFound
+		}
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Find returns a key of a user, keys of other users are not found
+func (r repo) Find(ctx context.Context, userID int, id int) (*APIKey, error) {
+	k := &APIKey{}
+
+	err := r.db.ModelContext(ctx, k).Where("id = ?", id).Where("user_id = ?", userID).First()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, errRepoKeyNotFound
+		}
+		return nil, err
+	}
+
+	return k, nil
+}
+
+func (r repo) List(ctx context.Context, userID int) ([]APIKey, error) {
+	keys := []APIKey{}
+
+	err := r.db.ModelContext(ctx, &keys).Where("user_id = ?", userID).Order("id ASC").Select()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (r repo) Update(ctx context.Context, k *APIKey, columns ...string) error {
+	res, err := r.db.ModelContext(ctx, k).
+		Column(append(columns, "updated_at")...).
+		WherePK().
+		Where("user_id = ?", k.UserID).
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoKeyNotFound
+	}
+
+	return nil
+}
+
+func (r repo) Delete(ctx context.Context, userID int, id int) error {
+	res, err := r.db.ModelContext(ctx, (*APIKey)(nil)).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoKeyNotFound
+	}
+
+	return nil
+}
+
+// Touch records the use of a key
+func (r repo) Touch(ctx context.Context, id int, at time.Time) error {
+	_, err := r.db.ModelContext(ctx, (*APIKey)(nil)).
+		Set("last_used_at = ?", at).
+		Where("id = ?", id).
+		Where("last_used_at IS NULL OR last_used_at < ?", at.Add(-touchInterval)).
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// NewRepository creates a new repository
+func NewRepository(
+	logger zerolog.Logger,
+	db *pg.DB,
+) Repository {
+	return &repo{
+		logger: logger,
+		db:     db,
+	}
+}
@@ -0,0 +1,288 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"go-api-template/internal/user"
+	"go-api-template/pkg/secret"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Service is a service provider
+type Service interface {
+	Create(ctx context.Context, userID int, name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error)
+	List(ctx context.Context, userID int) ([]APIKey, error)
+	Get(ctx context.Context, userID int, id int) (*APIKey, error)
+	Update(ctx context.Context, userID int, id int, upd *Update) (*APIKey, error)
+	Delete(ctx context.Context, userID int, id int) error
+	CheckAPIKey(ctx context.Context, key string) (int, *user.User, []string, error)
+}
+
+// Errors that can occur in the service
+var (
+	ErrInternalService = errors.New("internal service error")
+	ErrAPIKeyNotFound  = errors.New("api key not found")
+	ErrInvalidAPIKey   = errors.New("invalid or expired api key")
+	ErrInvalidName     = errors.New("api key name is required")
+	ErrInvalidScopes   = errors.New("api key scopes exceed the permissions of the user")
+	ErrInvalidExpiry   = errors.New("api key expiry is in the past")
+)
+
+// keys look like ak_<prefix>_<secret>, the prefix identifies the key
+const (
+	keyTag     = "ak_"
+	prefixSize = 6
+)
+
+type service struct {
+	logger zerolog.Logger
+	repo   Repository
+	users  user.Service
+}
+
+// Create creates a key for a user and returns it along with the key itself,
+// which is not stored and can not be shown again. A key can only be scoped to
+// permissions the user has.
+func (s service) Create(ctx context.Context, userID int, name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", ErrInvalidName
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrInvalidExpiry
+	}
+
+	err := s.checkScopes(ctx, userID, scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	b := make([]byte, prefixSize)
+	_, err = rand.Read(b)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, "", ErrInternalService
+	}
+	prefix := hex.EncodeToString(b)
+
+	sec, err := secret.Token(secret.DefaultSize)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, "", ErrInternalService
+	}
+
+	k, err := s.repo.Create(ctx, &APIKey{
+		UserID:     userID,
+		Name:       name,
+		Prefix:     prefix,
+		SecretHash: secret.Hash(sec),
+		Scopes:     uniqueScopes(scopes),
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, "", ErrInternalService
+	}
+
+	return k, keyTag + prefix + "_" + sec, nil
+}
+
+func (s service) List(ctx context.Context, userID int) ([]APIKey, error) {
+	keys, err := s.repo.List(ctx, userID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	return keys, nil
+}
+
+func (s service) Get(ctx context.Context, userID int, id int) (*APIKey, error) {
+	k, err := s.repo.Find(ctx, userID, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoKeyNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+
+		return nil, ErrInternalService
+	}
+
+	return k, nil
+}
+
+func (s service) Update(ctx context.Context, userID int, id int, upd *Update) (*APIKey, error) {
+	k, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []string{}
+
+	if upd.Name != nil {
+		name := strings.TrimSpace(*upd.Name)
+		if name == "" {
+			return nil, ErrInvalidName
+		}
+
+		k.Name = name
+		columns = append(columns, "name")
+	}
+
+	if upd.Scopes != nil {
+		err = s.checkScopes(ctx, userID, *upd.Scopes)
+		if err != nil {
+			return nil, err
+		}
+
+		k.Scopes = uniqueScopes(*upd.Scopes)
+		columns = append(columns, "scopes")
+	}
+
+	if len(columns) == 0 {
+		return k, nil
+	}
+
+	err = s.repo.Update(ctx, k, columns...)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoKeyNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+
+		return nil, ErrInternalService
+	}
+
+	return k, nil
+}
+
+func (s service) Delete(ctx context.Context, userID int, id int) error {
+	err := s.repo.Delete(ctx, userID, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoKeyNotFound) {
+			return ErrAPIKeyNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// CheckAPIKey returns the id and the owner of key along with the permissions
+// it grants, the scopes of the key the owner still has
+func (s service) CheckAPIKey(ctx context.Context, key string) (int, *user.User, []string, error) {
+	if !strings.HasPrefix(key, keyTag) {
+		return 0, nil, nil, ErrInvalidAPIKey
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(key, keyTag), "_", 2)
+	if len(parts) != 2 {
+		return 0, nil, nil, ErrInvalidAPIKey
+	}
+
+	k, err := s.repo.FindByPrefix(ctx, parts[0])
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoKeyNotFound) {
+			return 0, nil, nil, ErrInvalidAPIKey
+		}
+
+		return 0, nil, nil, ErrInternalService
+	}
+
+	if subtle.ConstantTimeCompare([]byte(secret.Hash(parts[1])), []byte(k.SecretHash)) != 1 {
+		return 0, nil, nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if k.Expired(now) {
+		return 0, nil, nil, ErrInvalidAPIKey
+	}
+
+	u, err := s.users.FindByID(ctx, k.UserID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return 0, nil, nil, ErrInvalidAPIKey
+		}
+
+		return 0, nil, nil, err
+	}
+
+	if !u.Active {
+		return 0, nil, nil, ErrInvalidAPIKey
+	}
+
+	err = s.repo.Touch(ctx, k.ID, now)
+	if err != nil {
+		s.logger.Error().Err(err).Int("api_key_id", k.ID).Msg("touch api key")
+	}
+
+	return k.ID, u, intersect(k.Scopes, u.Permissions()), nil
+}
+
+// checkScopes makes sure a user only hands out permissions it has
+func (s service) checkScopes(ctx context.Context, userID int, scopes []string) error {
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(intersect(scopes, u.Permissions())) != len(uniqueScopes(scopes)) {
+		return ErrInvalidScopes
+	}
+
+	return nil
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	out := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+
+	return out
+}
+
+// intersect returns the scopes found in permissions
+func intersect(scopes []string, permissions []string) []string {
+	granted := make(map[string]bool, len(permissions))
+	for _, p := range permissions {
+		granted[p] = true
+	}
+
+	out := []string{}
+	for _, s := range uniqueScopes(scopes) {
+		if granted[s] {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+// NewService creates a new service
+func NewService(
+	logger zerolog.Logger,
+	repo Repository,
+	users user.Service,
+) Service {
+	return &service{
+		logger: logger,
+		repo:   repo,
+		users:  users,
+	}
+}
@@ -0,0 +1,148 @@
+package apikey
+
+import (
+	"errors"
+	"go-api-template/internal/openapi"
+	"go-api-template/internal/user"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+// Transport handles transport for service
+type Transport struct {
+	logger        zerolog.Logger
+	srv           Service
+	currentUserID func(c echo.Context) int
+}
+
+// NewTransport creates a new transport
+func NewTransport(
+	logger zerolog.Logger,
+	srv Service,
+	currentUserID func(c echo.Context) int,
+) Transport {
+	return Transport{
+		logger:        logger,
+		srv:           srv,
+		currentUserID: currentUserID,
+	}
+}
+
+// ListApiKeys lists the API keys of the current user
+func (h Transport) ListApiKeys(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	keys, err := h.srv.List(ctx, h.currentUserID(c))
+	if err != nil {
+		return h.error(err)
+	}
+
+	res := openapi.ApiKeyList{
+		ApiKeys: make([]openapi.ApiKey, 0, len(keys)),
+	}
+	for i := range keys {
+		res.ApiKeys = append(res.ApiKeys, apiKey(&keys[i]))
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateApiKey creates an API key for the current user
+func (h Transport) CreateApiKey(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.ApiKeyCreateRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	k, key, err := h.srv.Create(ctx, h.currentUserID(c), req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		return h.error(err)
+	}
+
+	return c.JSON(http.StatusOK, openapi.ApiKeyCreateResponse{
+		ApiKey: apiKey(k),
+		Key:    key,
+	})
+}
+
+// GetApiKey returns an API key of the current user
+func (h Transport) GetApiKey(c echo.Context, id int) error {
+	ctx := c.Request().Context()
+
+	k, err := h.srv.Get(ctx, h.currentUserID(c), id)
+	if err != nil {
+		return h.error(err)
+	}
+
+	return c.JSON(http.StatusOK, apiKey(k))
+}
+
+// UpdateApiKey renames or rescopes an API key of the current user
+func (h Transport) UpdateApiKey(c echo.Context, id int) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.ApiKeyUpdateRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	k, err := h.srv.Update(ctx, h.currentUserID(c), id, &Update{
+		Name:   req.Name,
+		Scopes: req.Scopes,
+	})
+	if err != nil {
+		return h.error(err)
+	}
+
+	return c.JSON(http.StatusOK, apiKey(k))
+}
+
+// DeleteApiKey revokes an API key of the current user
+func (h Transport) DeleteApiKey(c echo.Context, id int) error {
+	ctx := c.Request().Context()
+
+	err := h.srv.Delete(ctx, h.currentUserID(c), id)
+	if err != nil {
+		return h.error(err)
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "api key deleted",
+	})
+}
+
+func (h Transport) error(err error) error {
+	h.logger.Err(err).Msg("")
+
+	switch {
+	case errors.Is(err, ErrAPIKeyNotFound), errors.Is(err, user.ErrUserNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrInvalidName), errors.Is(err, ErrInvalidExpiry):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrInvalidScopes):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
+	return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+}
+
+// apiKey maps a key to its representation, the secret hash never leaves
+func apiKey(k *APIKey) openapi.ApiKey {
+	return openapi.ApiKey{
+		Id:         k.ID,
+		Name:       k.Name,
+		Prefix:     keyTag + k.Prefix,
+		Scopes:     k.Scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Actions recorded in the audit log
+const (
+	ActionImpersonationStart = "impersonation.start"
+	ActionImpersonationStop  = "impersonation.stop"
+	ActionUserSuspend        = "user.suspend"
+	ActionUserReactivate     = "user.reactivate"
+	ActionUserDelete         = "user.delete"
+	ActionUserRestore        = "user.restore"
+	ActionUserPurge          = "user.purge"
+	ActionUserErase          = "user.erase"
+	ActionExportRequest      = "export.request"
+	ActionExportDownload     = "export.download"
+	ActionEmailChange        = "user.email_change"
+	ActionMobileChange       = "user.mobile_change"
+)
+
+// Entry is an action recorded in the audit log. ActorID is who took it,
+// UserID whose account it concerns.
+type Entry struct {
+	tableName struct{} `pg:"audit_log,alias:audit_log"`
+
+	ID      int               `pg:",pk"`
+	Action  string            `pg:",notnull"`
+	ActorID int               `pg:",notnull,use_zero"`
+	UserID  int               `pg:",notnull,use_zero"`
+	IP      string            `pg:",notnull,use_zero"`
+	Details map[string]string `pg:",notnull"`
+
+	CreatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *Entry) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+	if o.Details == nil {
+		o.Details = map[string]string{}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/rs/zerolog"
+)
+
+// Repository is data provider
+type Repository interface {
+	Create(ctx context.Context, e *Entry) error
+	Anonymize(ctx context.Context, userID int) error
+	List(ctx context.Context, userID int) ([]Entry, error)
+}
+
+type repo struct {
+	logger zerolog.Logger
+	db     *pg.DB
+}
+
+func (r repo) Create(ctx context.Context, e *Entry) error {
+	_, err := r.db.ModelContext(ctx, e).Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// Anonymize clears the ip and details of the entries concerning a user, the
+// entries themselves are kept
+func (r repo) Anonymize(ctx context.Context, userID int) error {
+	_, err := r.db.ModelContext(ctx, (*Entry)(nil)).
+		Set("ip = ''").
+		Set("details = '{}'").
+		Where("user_id = ?", userID).
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// List returns the entries a user took or that concern them, oldest first
+func (r repo) List(ctx context.Context, userID int) ([]Entry, error) {
+	entries := []Entry{}
+
+	err := r.db.ModelContext(ctx, &entries).
+		Where("user_id = ?", userID).
+		WhereOr("actor_id = ?", userID).
+		Order("created_at ASC", "id ASC").
+		Select()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// NewRepository creates a new repository
+func NewRepository(
+	logger zerolog.Logger,
+	db *pg.DB,
+) Repository {
+	return &repo{
+		logger: logger,
+		db:     db,
+	}
+}
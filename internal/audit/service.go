@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+)
+
+// Service is a service provider
+type Service interface {
+	Record(ctx context.Context, e *Entry) error
+	Anonymize(ctx context.Context, userID int) error
+	List(ctx context.Context, userID int) ([]Entry, error)
+}
+
+// Errors that can occur in the service
+var (
+	ErrInternalService = errors.New("internal service error")
+)
+
+type service struct {
+	logger zerolog.Logger
+	repo   Repository
+}
+
+// Record writes an entry to the audit log. Entries are logged as well so the
+// trail survives a failing database.
+func (s service) Record(ctx context.Context, e *Entry) error {
+	s.logger.Info().
+		Str("action", e.Action).
+		Int("actor_id", e.ActorID).
+		Int("user_id", e.UserID).
+		Str("ip", e.IP).
+		Interface("details", e.Details).
+		Msg("audit")
+
+	err := s.repo.Create(ctx, e)
+	if err != nil {
+		s.logger.Error().Err(err).Str("action", e.Action).Msg("record audit entry")
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// RecordOrLog writes an entry for an action that already happened. A failure
+// is only logged as Record logs the entry anyway.
+func RecordOrLog(ctx context.Context, s Service, logger zerolog.Logger, e *Entry) {
+	err := s.Record(ctx, e)
+	if err != nil {
+		logger.Err(err).Str("action", e.Action).Int("user_id", e.UserID).Msg("")
+	}
+}
+
+// Anonymize strips the personal data from the entries about a purged user
+func (s service) Anonymize(ctx context.Context, userID int) error {
+	err := s.repo.Anonymize(ctx, userID)
+	if err != nil {
+		s.logger.Error().Err(err).Int("user_id", userID).Msg("anonymize audit entries")
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// List returns the entries a user took or that concern them, oldest first
+func (s service) List(ctx context.Context, userID int) ([]Entry, error) {
+	entries, err := s.repo.List(ctx, userID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	return entries, nil
+}
+
+// NewService creates a new service
+func NewService(
+	logger zerolog.Logger,
+	repo Repository,
+) Service {
+	return &service{
+		logger: logger,
+		repo:   repo,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -15,7 +16,103 @@ type Config struct {
 		Port   string `yaml:"port"`
 		Env    string `yaml:"env"`
 		JWTKey string `yaml:"jwtKey"`
+		AppURL string `yaml:"appURL"`
 	} `yaml:"server"`
+	Auth struct {
+		AccessTokenTTL       time.Duration `yaml:"accessTokenTTL"`
+		RefreshTokenTTL      time.Duration `yaml:"refreshTokenTTL"`
+		RevocationCacheTTL   time.Duration `yaml:"revocationCacheTTL"`
+		Keyring              string        `yaml:"keyring"`
+		PasswordResetTTL     time.Duration `yaml:"passwordResetTTL"`
+		LinkSecret           string        `yaml:"linkSecret"`
+		VerifyEmail          bool          `yaml:"verifyEmail"`
+		EmailVerificationTTL time.Duration `yaml:"emailVerificationTTL"`
+		MFAKey               string        `yaml:"mfaKey"`
+		MFAIssuer            string        `yaml:"mfaIssuer"`
+		MFATokenTTL          time.Duration `yaml:"mfaTokenTTL"`
+		ImpersonationTTL     time.Duration `yaml:"impersonationTTL"`
+		Password             struct {
+			MinLength    int    `yaml:"minLength"`
+			MaxLength    int    `yaml:"maxLength"`
+			MinClasses   int    `yaml:"minClasses"`
+			BreachedList string `yaml:"breachedList"`
+		} `yaml:"password"`
+		TokenLookup string `yaml:"tokenLookup"`
+		Cookie      struct {
+			Refresh    string `yaml:"refresh"`
+			CSRFCookie string `yaml:"csrfCookie"`
+			CSRFHeader string `yaml:"csrfHeader"`
+			Domain     string `yaml:"domain"`
+			Secure     bool   `yaml:"secure"`
+		} `yaml:"cookie"`
+		ContactChange struct {
+			TTL         time.Duration `yaml:"ttl"`
+			MaxAttempts int           `yaml:"maxAttempts"`
+			MaxRequests int           `yaml:"maxRequests"`
+			Window      time.Duration `yaml:"window"`
+		} `yaml:"contactChange"`
+		PasswordReset struct {
+			MaxRequests   int           `yaml:"maxRequests"`
+			MaxIPRequests int           `yaml:"maxIPRequests"`
+			Window        time.Duration `yaml:"window"`
+		} `yaml:"passwordReset"`
+		MagicLink struct {
+			Enabled     bool          `yaml:"enabled"`
+			TTL         time.Duration `yaml:"ttl"`
+			MaxRequests int           `yaml:"maxRequests"`
+			Window      time.Duration `yaml:"window"`
+		} `yaml:"magicLink"`
+	} `yaml:"auth"`
+	OAuth struct {
+		Issuer         string        `yaml:"issuer"`
+		ConsentURL     string        `yaml:"consentURL"`
+		CodeTTL        time.Duration `yaml:"codeTTL"`
+		AccessTokenTTL time.Duration `yaml:"accessTokenTTL"`
+	} `yaml:"oauth"`
+	Accounts struct {
+		RestoreWindow time.Duration `yaml:"restoreWindow"`
+		Retention     time.Duration `yaml:"retention"`
+		PurgeInterval time.Duration `yaml:"purgeInterval"`
+		PurgeBatch    int           `yaml:"purgeBatch"`
+	} `yaml:"accounts"`
+	Privacy struct {
+		ExportTTL      time.Duration `yaml:"exportTTL"`
+		ExportInterval time.Duration `yaml:"exportInterval"`
+	} `yaml:"privacy"`
+	Throttle struct {
+		Store              string        `yaml:"store"`
+		MaxAccountFailures int           `yaml:"maxAccountFailures"`
+		MaxIPFailures      int           `yaml:"maxIPFailures"`
+		Window             time.Duration `yaml:"window"`
+		Lockout            time.Duration `yaml:"lockout"`
+		MaxLockout         time.Duration `yaml:"maxLockout"`
+	} `yaml:"throttle"`
+	Storage struct {
+		Driver  string `yaml:"driver"`
+		Dir     string `yaml:"dir"`
+		BaseURL string `yaml:"baseURL"`
+		S3      struct {
+			Endpoint  string `yaml:"endpoint"`
+			Region    string `yaml:"region"`
+			Bucket    string `yaml:"bucket"`
+			AccessKey string `yaml:"accessKey"`
+			SecretKey string `yaml:"secretKey"`
+			PathStyle bool   `yaml:"pathStyle"`
+		} `yaml:"s3"`
+	} `yaml:"storage"`
+	Avatar struct {
+		MaxSize   int64 `yaml:"maxSize"`
+		MaxPixels int   `yaml:"maxPixels"`
+	} `yaml:"avatar"`
+	Mail struct {
+		Driver string `yaml:"driver"`
+		From   string `yaml:"from"`
+		Dir    string `yaml:"dir"`
+	} `yaml:"mail"`
+	SMS struct {
+		Driver string `yaml:"driver"`
+		From   string `yaml:"from"`
+	} `yaml:"sms"`
 	DB struct {
 		Host     string `yaml:"host"`
 		User     string `yaml:"user"`
@@ -28,6 +125,53 @@ type Config struct {
 func New(cfgFile string) (*Config, error) {
 	cfg := &Config{}
 	cfg.Server.JWTKey = "secret"
+	cfg.Auth.AccessTokenTTL = 15 * time.Minute
+	cfg.Auth.RefreshTokenTTL = 30 * 24 * time.Hour
+	cfg.Auth.RevocationCacheTTL = 30 * time.Second
+	cfg.Auth.PasswordResetTTL = time.Hour
+	cfg.Auth.LinkSecret = "secret"
+	cfg.Auth.EmailVerificationTTL = 48 * time.Hour
+	cfg.Auth.MFAKey = "secret"
+	cfg.Auth.MFAIssuer = "go-api-template"
+	cfg.Auth.MFATokenTTL = 5 * time.Minute
+	cfg.Auth.ImpersonationTTL = 15 * time.Minute
+	cfg.Auth.Password.MinLength = 8
+	cfg.Auth.Password.MaxLength = 64
+	cfg.Auth.TokenLookup = "header:Authorization"
+	cfg.Auth.Cookie.Refresh = "refresh_token"
+	cfg.Auth.Cookie.CSRFCookie = "csrf_token"
+	cfg.Auth.Cookie.CSRFHeader = "X-CSRF-Token"
+	cfg.Auth.Cookie.Secure = true
+	cfg.Auth.ContactChange.TTL = 15 * time.Minute
+	cfg.Auth.ContactChange.MaxAttempts = 5
+	cfg.Auth.ContactChange.MaxRequests = 3
+	cfg.Auth.ContactChange.Window = time.Hour
+	cfg.Auth.PasswordReset.MaxRequests = 3
+	cfg.Auth.PasswordReset.MaxIPRequests = 20
+	cfg.Auth.PasswordReset.Window = time.Hour
+	cfg.Auth.MagicLink.TTL = 15 * time.Minute
+	cfg.Auth.MagicLink.MaxRequests = 3
+	cfg.Auth.MagicLink.Window = 15 * time.Minute
+	cfg.OAuth.CodeTTL = 5 * time.Minute
+	cfg.OAuth.AccessTokenTTL = time.Hour
+	cfg.Accounts.RestoreWindow = 30 * 24 * time.Hour
+	cfg.Accounts.Retention = 30 * 24 * time.Hour
+	cfg.Accounts.PurgeInterval = time.Hour
+	cfg.Accounts.PurgeBatch = 100
+	cfg.Privacy.ExportTTL = 7 * 24 * time.Hour
+	cfg.Privacy.ExportInterval = 10 * time.Second
+	cfg.Throttle.Store = "postgres"
+	cfg.Throttle.MaxAccountFailures = 5
+	cfg.Throttle.MaxIPFailures = 50
+	cfg.Throttle.Window = 15 * time.Minute
+	cfg.Throttle.Lockout = time.Minute
+	cfg.Throttle.MaxLockout = time.Hour
+	cfg.Storage.Driver = "fs"
+	cfg.Storage.Dir = "./tmp/storage"
+	cfg.Avatar.MaxSize = 5 << 20
+	cfg.Avatar.MaxPixels = 25000000
+	cfg.Mail.Driver = "log"
+	cfg.SMS.Driver = "log"
 	if len(cfgFile) == 0 {
 		return cfg, fmt.Errorf("invalid config file %s", cfgFile)
 	}
@@ -47,5 +191,20 @@ func New(cfgFile string) (*Config, error) {
 		return cfg, fmt.Errorf("yaml decoder error : %v", err)
 	}
 
+	if cfg.OAuth.Issuer == "" {
+		cfg.OAuth.Issuer = fmt.Sprintf("http://%s:%s", cfg.Server.Host, cfg.Server.Port)
+	}
+	if cfg.OAuth.ConsentURL == "" {
+		cfg.OAuth.ConsentURL = cfg.Server.AppURL + "/oauth/consent"
+	}
+	if cfg.Storage.BaseURL == "" && cfg.Storage.Driver == "fs" {
+		// the server publishes the directory itself
+		cfg.Storage.BaseURL = fmt.Sprintf("http://%s:%s/media", cfg.Server.Host, cfg.Server.Port)
+	}
+	if cfg.Accounts.Retention < cfg.Accounts.RestoreWindow {
+		// a purged account could not be restored anyway
+		cfg.Accounts.Retention = cfg.Accounts.RestoreWindow
+	}
+
 	return cfg, nil
 }
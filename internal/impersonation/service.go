@@ -0,0 +1,165 @@
+package impersonation
+
+import (
+	"context"
+	"errors"
+	"go-api-template/internal/audit"
+	"go-api-template/internal/security"
+	"go-api-template/internal/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Service is a service provider
+type Service interface {
+	Start(ctx context.Context, adminID, userID int, reason, ip string) (*Token, error)
+	Stop(ctx context.Context, claims *security.JwtClaims, ip string) error
+}
+
+// Errors that can occur in the service
+var (
+	ErrInternalService  = errors.New("internal service error")
+	ErrReasonRequired   = errors.New("a reason is required to impersonate a user")
+	ErrImpersonateSelf  = errors.New("can not impersonate yourself")
+	ErrImpersonateAdmin = errors.New("can not impersonate an admin")
+	ErrNotImpersonating = errors.New("token is not an impersonation token")
+)
+
+// adminPermission is the permission that makes a user an admin
+const adminPermission = "admin"
+
+// Token is an impersonation access token. It can not be refreshed.
+type Token struct {
+	AccessToken string
+	ExpiresIn   int
+}
+
+type service struct {
+	logger zerolog.Logger
+	users  user.Service
+	audit  audit.Service
+	sign   func(claims jwt.Claims) (string, error)
+	revoke func(ctx context.Context, claims *security.JwtClaims) error
+	ttl    time.Duration
+}
+
+// Start issues a token acting as a user on behalf of an admin. Admins can not
+// be impersonated, an impersonator would gain their permissions.
+func (s service) Start(ctx context.Context, adminID, userID int, reason, ip string) (*Token, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+
+	if adminID == userID {
+		return nil, ErrImpersonateSelf
+	}
+
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	for _, p := range u.Permissions() {
+		if p == adminPermission {
+			return nil, ErrImpersonateAdmin
+		}
+	}
+
+	now := time.Now()
+	claims := &security.JwtClaims{
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		UserID:      u.ID,
+		Roles:       u.RoleNames(),
+		Permissions: u.Permissions(),
+		Act: &security.Actor{
+			UserID: adminID,
+		},
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
+			ExpiresAt: now.Add(s.ttl).Unix(),
+		},
+	}
+	claims.SetIssuedAt(now)
+
+	// no token without a trace of it
+	err = s.audit.Record(ctx, &audit.Entry{
+		Action:  audit.ActionImpersonationStart,
+		ActorID: adminID,
+		UserID:  u.ID,
+		IP:      ip,
+		Details: map[string]string{
+			"jti":        claims.Id,
+			"reason":     reason,
+			"expires_at": strconv.FormatInt(claims.ExpiresAt, 10),
+		},
+	})
+	if err != nil {
+		return nil, ErrInternalService
+	}
+
+	t, err := s.sign(claims)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("sign impersonation token")
+		return nil, ErrInternalService
+	}
+
+	return &Token{
+		AccessToken: t,
+		ExpiresIn:   int(s.ttl.Seconds()),
+	}, nil
+}
+
+// Stop revokes an impersonation token before it expires
+func (s service) Stop(ctx context.Context, claims *security.JwtClaims, ip string) error {
+	if claims.Act == nil {
+		return ErrNotImpersonating
+	}
+
+	err := s.revoke(ctx, claims)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	err = s.audit.Record(ctx, &audit.Entry{
+		Action:  audit.ActionImpersonationStop,
+		ActorID: claims.Act.UserID,
+		UserID:  claims.UserID,
+		IP:      ip,
+		Details: map[string]string{
+			"jti": claims.Id,
+		},
+	})
+	if err != nil {
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// NewService creates a new service
+func NewService(
+	logger zerolog.Logger,
+	users user.Service,
+	audit audit.Service,
+	sign func(claims jwt.Claims) (string, error),
+	revoke func(ctx context.Context, claims *security.JwtClaims) error,
+	ttl time.Duration,
+) Service {
+	return &service{
+		logger: logger,
+		users:  users,
+		audit:  audit,
+		sign:   sign,
+		revoke: revoke,
+		ttl:    ttl,
+	}
+}
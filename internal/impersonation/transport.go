@@ -0,0 +1,84 @@
+package impersonation
+
+import (
+	"errors"
+	"go-api-template/internal/openapi"
+	"go-api-template/internal/security"
+	"go-api-template/internal/user"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+// Transport handles transport for service
+type Transport struct {
+	logger        zerolog.Logger
+	srv           Service
+	currentClaims func(c echo.Context) *security.JwtClaims
+}
+
+// NewTransport creates a new transport
+func NewTransport(
+	logger zerolog.Logger,
+	srv Service,
+	currentClaims func(c echo.Context) *security.JwtClaims,
+) Transport {
+	return Transport{
+		logger:        logger,
+		srv:           srv,
+		currentClaims: currentClaims,
+	}
+}
+
+// ImpersonateUser issues a short lived token acting as a user
+func (h Transport) ImpersonateUser(c echo.Context, id int) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.ImpersonationRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	t, err := h.srv.Start(ctx, h.currentClaims(c).UserID, id, req.Reason, c.RealIP())
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		switch {
+		case errors.Is(err, ErrReasonRequired), errors.Is(err, ErrImpersonateSelf):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, ErrImpersonateAdmin):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case errors.Is(err, user.ErrUserNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.ImpersonationResponse{
+		Token:     t.AccessToken,
+		ExpiresIn: t.ExpiresIn,
+		UserId:    id,
+	})
+}
+
+// StopImpersonation revokes the impersonation token used for the request
+func (h Transport) StopImpersonation(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.srv.Stop(ctx, h.currentClaims(c), c.RealIP())
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrNotImpersonating) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "impersonation stopped",
+	})
+}
@@ -0,0 +1,46 @@
+package mfa
+
+import (
+	"context"
+	"time"
+)
+
+// Secret is the TOTP secret of a user, encrypted. It only protects logins
+// once confirmed with a first code.
+type Secret struct {
+	tableName struct{} `pg:"mfa_secrets,alias:mfa_secrets"`
+
+	UserID      int    `pg:",pk"`
+	Secret      string `pg:",notnull"`
+	LastCounter int64  `pg:",notnull,use_zero"`
+	ConfirmedAt *time.Time
+
+	CreatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *Secret) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+
+	return c, nil
+}
+
+// RecoveryCode is a one time code that replaces a TOTP code when the device
+// is lost. Only a hash of the code is stored.
+type RecoveryCode struct {
+	tableName struct{} `pg:"mfa_recovery_codes,alias:mfa_recovery_codes"`
+
+	ID       int    `pg:",pk"`
+	UserID   int    `pg:",notnull"`
+	CodeHash string `pg:",notnull"`
+	UsedAt   *time.Time
+
+	CreatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *RecoveryCode) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+
+	return c, nil
+}
@@ -0,0 +1,206 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/rs/zerolog"
+)
+
+// Repository is data provider
+type Repository interface {
+	FindSecret(ctx context.Context, userID int) (*Secret, error)
+	SaveSecret(ctx context.Context, s *Secret) error
+	Confirm(ctx context.Context, userID int, counter int64, codeHashes []string) error
+	UseCounter(ctx context.Context, userID int, counter int64) error
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string) error
+	ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error
+	Delete(ctx context.Context, userID int) error
+}
+
+var (
+	errRepoSecretNotFound  = errors.New("mfa secret not found")
+	errRepoAlreadyEnabled  = errors.New("mfa already enabled")
+	errRepoCodeUsed        = errors.New("code already used")
+	errRepoRecoveryInvalid = errors.New("recovery code not found or used")
+)
+
+type repo struct {
+	logger zerolog.Logger
+	db     *pg.DB
+}
+
+func (r repo) FindSecret(ctx context.Context, userID int) (*Secret, error) {
+	s := &Secret{}
+
+	err := r.db.ModelContext(ctx, s).Where("user_id = ?", userID).First()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, errRepoSecretNotFound
+		}
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// SaveSecret stores a new unconfirmed secret, replacing a previous unconfirmed
+// one. A confirmed secret is never replaced.
+func (r repo) SaveSecret(ctx context.Context, s *Secret) error {
+	res, err := r.db.ModelContext(ctx, s).
+		OnConflict("(user_id) DO UPDATE").
+		Set("secret = EXCLUDED.secret").
+		Set("last_counter = EXCLUDED.last_counter").
+		Set("created_at = EXCLUDED.created_at").
+		Where("mfa_secrets.confirmed_at IS NULL").
+		Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoAlreadyEnabled
+	}
+
+	return nil
+}
+
+// Confirm enables the secret of a user along with its first recovery codes
+func (r repo) Confirm(ctx context.Context, userID int, counter int64, codeHashes []string) error {
+	err := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		res, err := tx.ModelContext(ctx, (*Secret)(nil)).
+			Set("confirmed_at = ?", time.Now()).
+			Set("last_counter = ?", counter).
+			Where("user_id = ?", userID).
+			Where("confirmed_at IS NULL").
+			Update()
+		if err != nil {
+			return err
+		}
+
+		if res.RowsAffected() == 0 {
+			return errRepoAlreadyEnabled
+		}
+
+		return replaceRecoveryCodes(ctx, tx, userID, codeHashes)
+	})
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// UseCounter records the time step of an accepted code. Only one caller can
+// use a given step, everyone else gets errRepoCodeUsed.
+func (r repo) UseCounter(ctx context.Context, userID int, counter int64) error {
+	res, err := r.db.ModelContext(ctx, (*Secret)(nil)).
+		Set("last_counter = ?", counter).
+		Where("user_id = ?", userID).
+		Where("last_counter < ?", counter).
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoCodeUsed
+	}
+
+	return nil
+}
+
+func (r repo) UseRecoveryCode(ctx context.Context, userID int, codeHash string) error {
+	res, err := r.db.ModelContext(ctx, (*RecoveryCode)(nil)).
+		Set("used_at = ?", time.Now()).
+		Where("user_id = ?", userID).
+		Where("code_hash = ?", codeHash).
+		Where("used_at IS NULL").
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoRecoveryInvalid
+	}
+
+	return nil
+}
+
+func (r repo) ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error {
+	err := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return replaceRecoveryCodes(ctx, tx, userID, codeHashes)
+	})
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *pg.Tx, userID int, codeHashes []string) error {
+	_, err := tx.ModelContext(ctx, (*RecoveryCode)(nil)).
+		Where("user_id = ?", userID).
+		Delete()
+	if err != nil {
+		return err
+	}
+
+	codes := make([]*RecoveryCode, 0, len(codeHashes))
+	for _, h := range codeHashes {
+		codes = append(codes, &RecoveryCode{
+			UserID:   userID,
+			CodeHash: h,
+		})
+	}
+
+	if len(codes) == 0 {
+		return nil
+	}
+
+	_, err = tx.ModelContext(ctx, &codes).Insert()
+	return err
+}
+
+// Delete disables mfa for a user
+func (r repo) Delete(ctx context.Context, userID int) error {
+	err := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.ModelContext(ctx, (*RecoveryCode)(nil)).
+			Where("user_id = ?", userID).
+			Delete()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ModelContext(ctx, (*Secret)(nil)).
+			Where("user_id = ?", userID).
+			Delete()
+		return err
+	})
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// NewRepository creates a new repository
+func NewRepository(
+	logger zerolog.Logger,
+	db *pg.DB,
+) Repository {
+	return &repo{
+		logger: logger,
+		db:     db,
+	}
+}
@@ -0,0 +1,302 @@
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"go-api-template/internal/config"
+	"go-api-template/internal/user"
+	"go-api-template/pkg/secret"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Service is a service provider
+type Service interface {
+	Enroll(ctx context.Context, userID int, account string) (*Enrollment, error)
+	Confirm(ctx context.Context, userID int, code string) ([]string, error)
+	Enabled(ctx context.Context, userID int) (bool, error)
+	Verify(ctx context.Context, userID int, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID int, code string) ([]string, error)
+	Disable(ctx context.Context, userID int, code string) error
+}
+
+// Errors that can occur in the service
+var (
+	ErrInternalService = errors.New("internal service error")
+	ErrAlreadyEnabled  = errors.New("two-factor authentication already enabled")
+	ErrNotEnabled      = errors.New("two-factor authentication not enabled")
+	ErrNotEnrolled     = errors.New("two-factor authentication not enrolled")
+	ErrInvalidCode     = user.ErrInvalidCode
+)
+
+// Enrollment is what a user needs to add a TOTP secret to an authenticator app
+type Enrollment struct {
+	Secret string
+	URI    string
+}
+
+// recovery codes are recoveryCodeLength characters out of recoveryAlphabet,
+// shown in groups of 4
+const (
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 16
+	recoveryAlphabet   = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+type service struct {
+	logger zerolog.Logger
+	repo   Repository
+	key    []byte
+	issuer string
+}
+
+// Enroll creates a new secret for a user. It has to be confirmed with a
+// first code before it protects logins.
+func (s service) Enroll(ctx context.Context, userID int, account string) (*Enrollment, error) {
+	totp, err := generateSecret()
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	enc, err := secret.Encrypt(s.key, []byte(totp))
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	err = s.repo.SaveSecret(ctx, &Secret{
+		UserID: userID,
+		Secret: enc,
+	})
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoAlreadyEnabled) {
+			return nil, ErrAlreadyEnabled
+		}
+
+		return nil, ErrInternalService
+	}
+
+	return &Enrollment{
+		Secret: totp,
+		URI:    otpauthURI(s.issuer, account, totp),
+	}, nil
+}
+
+// Confirm enables two-factor authentication with the first code of the
+// enrolled secret and returns the recovery codes. They are never shown again.
+func (s service) Confirm(ctx context.Context, userID int, code string) ([]string, error) {
+	sec, totp, err := s.secret(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotEnabled) {
+			return nil, ErrNotEnrolled
+		}
+
+		return nil, err
+	}
+
+	if sec.ConfirmedAt != nil {
+		return nil, ErrAlreadyEnabled
+	}
+
+	counter, ok := matchTOTP(totp, normalizeCode(code), time.Now(), sec.LastCounter)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	err = s.repo.Confirm(ctx, userID, counter, hashes)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoAlreadyEnabled) {
+			return nil, ErrAlreadyEnabled
+		}
+
+		return nil, ErrInternalService
+	}
+
+	return codes, nil
+}
+
+// Enabled reports whether logins of a user need a second factor
+func (s service) Enabled(ctx context.Context, userID int) (bool, error) {
+	sec, err := s.repo.FindSecret(ctx, userID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoSecretNotFound) {
+			return false, nil
+		}
+
+		return false, ErrInternalService
+	}
+
+	return sec.ConfirmedAt != nil, nil
+}
+
+// Verify checks a TOTP code or a recovery code. Every code is accepted only
+// once.
+func (s service) Verify(ctx context.Context, userID int, code string) error {
+	sec, totp, err := s.secret(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if sec.ConfirmedAt == nil {
+		return ErrNotEnabled
+	}
+
+	code = normalizeCode(code)
+
+	if len(code) == recoveryCodeLength {
+		err = s.repo.UseRecoveryCode(ctx, userID, secret.Hash(code))
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("")
+			if errors.Is(err, errRepoRecoveryInvalid) {
+				return ErrInvalidCode
+			}
+
+			return ErrInternalService
+		}
+
+		s.logger.Info().Int("user_id", userID).Msg("recovery code used")
+
+		return nil
+	}
+
+	counter, ok := matchTOTP(totp, code, time.Now(), sec.LastCounter)
+	if !ok {
+		return ErrInvalidCode
+	}
+
+	err = s.repo.UseCounter(ctx, userID, counter)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoCodeUsed) {
+			return ErrInvalidCode
+		}
+
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user, the old ones
+// stop working
+func (s service) RegenerateRecoveryCodes(ctx context.Context, userID int, code string) ([]string, error) {
+	err := s.Verify(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	err = s.repo.ReplaceRecoveryCodes(ctx, userID, hashes)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	return codes, nil
+}
+
+// Disable turns two-factor authentication off, it takes a valid code
+func (s service) Disable(ctx context.Context, userID int, code string) error {
+	err := s.Verify(ctx, userID, code)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Delete(ctx, userID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// secret loads and decrypts the secret of a user
+func (s service) secret(ctx context.Context, userID int) (*Secret, string, error) {
+	sec, err := s.repo.FindSecret(ctx, userID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoSecretNotFound) {
+			return nil, "", ErrNotEnabled
+		}
+
+		return nil, "", ErrInternalService
+	}
+
+	totp, err := secret.Decrypt(s.key, sec.Secret)
+	if err != nil {
+		s.logger.Error().Err(err).Int("user_id", userID).Msg("decrypt mfa secret")
+		return nil, "", ErrInternalService
+	}
+
+	return sec, string(totp), nil
+}
+
+// generateRecoveryCodes returns new recovery codes, formatted for display,
+// along with the hashes to store
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	b := make([]byte, recoveryCodeLength)
+	for i := 0; i < recoveryCodeCount; i++ {
+		_, err := rand.Read(b)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		code := make([]byte, recoveryCodeLength)
+		for j := range b {
+			// the alphabet has 32 characters so the modulo is not biased
+			code[j] = recoveryAlphabet[int(b[j])%len(recoveryAlphabet)]
+		}
+
+		display := make([]string, 0, recoveryCodeLength/4)
+		for j := 0; j < recoveryCodeLength; j += 4 {
+			display = append(display, string(code[j:j+4]))
+		}
+
+		codes = append(codes, strings.Join(display, "-"))
+		hashes = append(hashes, secret.Hash(string(code)))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeCode drops the separators users type along with codes
+func normalizeCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
+
+// NewService creates a new service
+func NewService(
+	logger zerolog.Logger,
+	repo Repository,
+	cfg *config.Config,
+) Service {
+	return &service{
+		logger: logger,
+		repo:   repo,
+		key:    []byte(cfg.Auth.MFAKey),
+		issuer: cfg.Auth.MFAIssuer,
+	}
+}
@@ -0,0 +1,234 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	"go-api-template/internal/openapi"
+	"go-api-template/internal/user"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+// retryError is an error telling the client to come back later
+type retryError interface {
+	error
+	RetryAfter() time.Duration
+}
+
+// Transport handles transport for service
+type Transport struct {
+	logger        zerolog.Logger
+	srv           Service
+	users         user.Service
+	throttle      user.Throttle
+	currentUserID func(c echo.Context) int
+}
+
+// NewTransport creates a new transport
+func NewTransport(
+	logger zerolog.Logger,
+	srv Service,
+	users user.Service,
+	throttle user.Throttle,
+	currentUserID func(c echo.Context) int,
+) Transport {
+	return Transport{
+		logger:        logger,
+		srv:           srv,
+		users:         users,
+		throttle:      throttle,
+		currentUserID: currentUserID,
+	}
+}
+
+// EnrollTotp creates a TOTP secret for the current user
+func (h Transport) EnrollTotp(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	u, err := h.users.FindByID(ctx, h.currentUserID(c))
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, user.ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	e, err := h.srv.Enroll(ctx, u.ID, u.Email)
+	if err != nil {
+		return h.error(err)
+	}
+
+	return c.JSON(http.StatusOK, openapi.TotpEnrollment{
+		Secret: e.Secret,
+		Uri:    e.URI,
+	})
+}
+
+// ConfirmTotp enables two-factor authentication for the current user
+func (h Transport) ConfirmTotp(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.MfaCodeRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	id := h.currentUserID(c)
+
+	// codes are guessed as easily here as at login, with a stolen token
+	err = h.throttle.CheckSecondFactor(ctx, id, c.RealIP())
+	if err != nil {
+		return h.throttleError(err)
+	}
+
+	codes, err := h.srv.Confirm(ctx, id, req.Code)
+	if err != nil {
+		return h.fail(c, id, err)
+	}
+
+	h.succeed(ctx, id)
+
+	return c.JSON(http.StatusOK, openapi.RecoveryCodes{
+		Codes: codes,
+	})
+}
+
+// DisableTotp disables two-factor authentication for the current user
+func (h Transport) DisableTotp(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.MfaChangeRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	id := h.currentUserID(c)
+
+	err = h.verify(c, id, req.Password)
+	if err != nil {
+		return err
+	}
+
+	err = h.srv.Disable(ctx, id, req.Code)
+	if err != nil {
+		return h.fail(c, id, err)
+	}
+
+	h.succeed(ctx, id)
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "two-factor authentication disabled",
+	})
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the current user
+func (h Transport) RegenerateRecoveryCodes(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.MfaChangeRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	id := h.currentUserID(c)
+
+	err = h.verify(c, id, req.Password)
+	if err != nil {
+		return err
+	}
+
+	codes, err := h.srv.RegenerateRecoveryCodes(ctx, id, req.Code)
+	if err != nil {
+		return h.fail(c, id, err)
+	}
+
+	h.succeed(ctx, id)
+
+	return c.JSON(http.StatusOK, openapi.RecoveryCodes{
+		Codes: codes,
+	})
+}
+
+// verify checks the throttle of the second factor and the password of the
+// current user, both are needed to turn off or replace a second factor
+func (h Transport) verify(c echo.Context, id int, password string) error {
+	ctx := c.Request().Context()
+
+	err := h.throttle.CheckSecondFactor(ctx, id, c.RealIP())
+	if err != nil {
+		return h.throttleError(err)
+	}
+
+	err = h.users.VerifyPassword(ctx, id, password)
+	if err != nil {
+		return h.fail(c, id, err)
+	}
+
+	return nil
+}
+
+// fail counts a wrong code or password against the second factor throttle
+// and maps err to a response
+func (h Transport) fail(c echo.Context, id int, err error) error {
+	if errors.Is(err, ErrInvalidCode) || errors.Is(err, user.ErrIncorrectPassword) {
+		ferr := h.throttle.FailSecondFactor(c.Request().Context(), id, c.RealIP())
+		if ferr != nil {
+			h.logger.Err(err).Msg("")
+			return h.throttleError(ferr)
+		}
+	}
+
+	return h.error(err)
+}
+
+// succeed forgets the failures of the second factor of a user. A failure is
+// only logged as the change was made.
+func (h Transport) succeed(ctx context.Context, id int) {
+	err := h.throttle.SucceedSecondFactor(ctx, id)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+	}
+}
+
+// throttleError maps an error of the throttle to a response, 429 with the
+// time to wait when locked
+func (h Transport) throttleError(err error) error {
+	h.logger.Err(err).Msg("")
+
+	var retry retryError
+	if errors.As(err, &retry) {
+		return echo.NewHTTPError(http.StatusTooManyRequests, err.Error()).SetInternal(err)
+	}
+
+	return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+}
+
+func (h Transport) error(err error) error {
+	h.logger.Err(err).Msg("")
+
+	switch {
+	case errors.Is(err, user.ErrIncorrectPassword):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case errors.Is(err, user.ErrUserNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrInvalidCode):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrAlreadyEnabled):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, ErrNotEnabled), errors.Is(err, ErrNotEnrolled):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,142 @@
+package oauth
+
+import (
+	"context"
+	"time"
+)
+
+// Client is an application allowed to ask users for access. Confidential
+// clients authenticate with a secret, public clients such as mobile apps have
+// none and rely on PKCE alone.
+type Client struct {
+	tableName struct{} `pg:"oauth_clients,alias:oauth_clients"`
+
+	ID           int      `pg:",pk"`
+	ClientID     string   `pg:",unique,notnull"`
+	SecretHash   string   `pg:",notnull,use_zero"`
+	Name         string   `pg:",notnull"`
+	RedirectURIs []string `pg:",array,notnull"`
+	Scopes       []string `pg:",array,notnull"`
+
+	CreatedAt time.Time `pg:",notnull"`
+	UpdatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *Client) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+	o.UpdatedAt = time.Now()
+
+	return c, nil
+}
+
+// BeforeUpdate Before Update trigger
+func (o *Client) BeforeUpdate(c context.Context) (context.Context, error) {
+	o.UpdatedAt = time.Now()
+
+	return c, nil
+}
+
+// Confidential reports whether the client has a secret
+func (o *Client) Confidential() bool {
+	return o.SecretHash != ""
+}
+
+// AllowsRedirect reports whether uri is one of the registered redirect URIs.
+// URIs are compared exactly, as RFC 6749 recommends.
+func (o *Client) AllowsRedirect(uri string) bool {
+	for _, u := range o.RedirectURIs {
+		if u == uri {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuthorizationCode is a single use code a client exchanges for a token.
+// Only a hash of the code is stored.
+type AuthorizationCode struct {
+	tableName struct{} `pg:"oauth_authorization_codes,alias:oauth_authorization_codes"`
+
+	CodeHash      string   `pg:",pk"`
+	ClientID      string   `pg:",notnull"`
+	UserID        int      `pg:",notnull"`
+	RedirectURI   string   `pg:",notnull"`
+	Scopes        []string `pg:",array,notnull"`
+	CodeChallenge string   `pg:",notnull"`
+	Nonce         string   `pg:",notnull,use_zero"`
+
+	ExpiresAt time.Time `pg:",notnull"`
+	CreatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *AuthorizationCode) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+
+	return c, nil
+}
+
+// Consent records the scopes a user granted to a client, so the user is not
+// asked again for them
+type Consent struct {
+	tableName struct{} `pg:"oauth_consents,alias:oauth_consents"`
+
+	UserID   int      `pg:",pk"`
+	ClientID string   `pg:",pk"`
+	Scopes   []string `pg:",array,notnull"`
+
+	CreatedAt time.Time `pg:",notnull"`
+	UpdatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *Consent) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+	o.UpdatedAt = time.Now()
+
+	return c, nil
+}
+
+// Covers reports whether the consent includes every one of scopes
+func (o *Consent) Covers(scopes []string) bool {
+	if o == nil {
+		return false
+	}
+
+	return len(intersect(scopes, o.Scopes)) == len(scopes)
+}
+
+// AuthorizationRequest is the request of a client for a user's authorization
+type AuthorizationRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+}
+
+// Token is an issued access token
+type Token struct {
+	AccessToken string
+	ExpiresIn   int
+	Scopes      []string
+
+	// IDToken is set when the openid scope was granted
+	IDToken string
+}
+
+// Introspection is the state of a token (RFC 7662 2.2). Only Active is set
+// for tokens that are not active.
+type Introspection struct {
+	Active    bool
+	Subject   string
+	ClientID  string
+	Scopes    []string
+	ExpiresAt int64
+	IssuedAt  int64
+}
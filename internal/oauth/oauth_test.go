@@ -0,0 +1,520 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"go-api-template/internal/config"
+	"go-api-template/internal/openapi"
+	"go-api-template/internal/security"
+	"go-api-template/internal/user"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+// memoryRepo keeps clients, codes and consents in memory
+type memoryRepo struct {
+	clients  map[string]Client
+	codes    map[string]AuthorizationCode
+	consents map[string]Consent
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		clients:  map[string]Client{},
+		codes:    map[string]AuthorizationCode{},
+		consents: map[string]Consent{},
+	}
+}
+
+func (r *memoryRepo) CreateClient(ctx context.Context, c *Client) (*Client, error) {
+	if _, ok := r.clients[c.ClientID]; ok {
+		return nil, errRepoClientAlreadyExists
+	}
+
+	r.clients[c.ClientID] = *c
+	return c, nil
+}
+
+func (r *memoryRepo) FindClient(ctx context.Context, clientID string) (*Client, error) {
+	c, ok := r.clients[clientID]
+	if !ok {
+		return nil, errRepoClientNotFound
+	}
+
+	return &c, nil
+}
+
+func (r *memoryRepo) ListClients(ctx context.Context) ([]Client, error) {
+	clients := []Client{}
+	for _, c := range r.clients {
+		clients = append(clients, c)
+	}
+
+	return clients, nil
+}
+
+func (r *memoryRepo) DeleteClient(ctx context.Context, clientID string) error {
+	if _, ok := r.clients[clientID]; !ok {
+		return errRepoClientNotFound
+	}
+
+	delete(r.clients, clientID)
+	return nil
+}
+
+func (r *memoryRepo) CreateCode(ctx context.Context, code *AuthorizationCode) error {
+	r.codes[code.CodeHash] = *code
+	return nil
+}
+
+func (r *memoryRepo) ConsumeCode(ctx context.Context, hash string) (*AuthorizationCode, error) {
+	ac, ok := r.codes[hash]
+	if !ok || ac.ExpiresAt.Before(time.Now()) {
+		return nil, errRepoCodeNotFound
+	}
+
+	delete(r.codes, hash)
+	return &ac, nil
+}
+
+func (r *memoryRepo) FindConsent(ctx context.Context, userID int, clientID string) (*Consent, error) {
+	c, ok := r.consents[clientID]
+	if !ok || c.UserID != userID {
+		return nil, errRepoConsentNotFound
+	}
+
+	return &c, nil
+}
+
+func (r *memoryRepo) SaveConsent(ctx context.Context, consent *Consent) error {
+	r.consents[consent.ClientID] = *consent
+	return nil
+}
+
+func (r *memoryRepo) ListConsents(ctx context.Context, userID int) ([]Consent, error) {
+	consents := []Consent{}
+	for _, c := range r.consents {
+		if c.UserID == userID {
+			consents = append(consents, c)
+		}
+	}
+
+	return consents, nil
+}
+
+func (r *memoryRepo) DeleteConsent(ctx context.Context, userID int, clientID string) error {
+	if _, err := r.FindConsent(ctx, userID, clientID); err != nil {
+		return err
+	}
+
+	delete(r.consents, clientID)
+	return nil
+}
+
+// users knows a single admin
+type users struct {
+	user.Service
+	user user.User
+}
+
+func (s users) FindByID(ctx context.Context, id int) (*user.User, error) {
+	if id != s.user.ID {
+		return nil, user.ErrUserNotFound
+	}
+
+	u := s.user
+	return &u, nil
+}
+
+func (s users) ImageURL(u *user.User) string {
+	return ""
+}
+
+type noRevocations struct{}
+
+func (noRevocations) IsRevoked(ctx context.Context, claims *security.JwtClaims) (bool, error) {
+	return false, nil
+}
+
+type noAPIKeys struct{}
+
+func (noAPIKeys) CheckAPIKey(ctx context.Context, key string) (int, *user.User, []string, error) {
+	return 0, nil, nil, security.ErrJWTInvalid
+}
+
+const (
+	redirectURI = "https://client.example.com/callback"
+	consentURL  = "https://app.example.com/consent"
+)
+
+// server is the API with the OAuth endpoints and a user to authorize clients
+type server struct {
+	e         *echo.Echo
+	srv       Service
+	userToken string
+
+	// createdAPIKey is set once the createApiKey handler was reached
+	createdAPIKey bool
+}
+
+func newServer(t *testing.T) *server {
+	cfg := &config.Config{}
+	cfg.Server.JWTKey = "secret"
+	cfg.Auth.TokenLookup = "header:Authorization"
+	cfg.OAuth.Issuer = "https://api.example.com"
+	cfg.OAuth.CodeTTL = time.Minute
+	cfg.OAuth.AccessTokenTTL = time.Hour
+
+	keys, err := security.OpenKeyring(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookup, err := security.ParseTokenLookup(cfg.Auth.TokenLookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	us := users{user: user.User{
+		ID:        1,
+		FirstName: "Ada",
+		LastName:  "Lovelace",
+		Email:     "ada@example.com",
+		Active:    true,
+		Roles:     []user.Role{{Name: "admin", Permissions: []string{"admin"}}},
+	}}
+
+	repo := newMemoryRepo()
+	validator := security.NewValidator(keys, us.FindByID, noRevocations{}, NewGrantChecker(zerolog.Nop(), repo))
+	srv := NewService(zerolog.Nop(), repo, us, keys.Sign, validator.Validate, cfg)
+	transport := NewTransport(zerolog.Nop(), srv, us, security.GetClaimFromEchoContext, consentURL)
+
+	userToken, _, err := security.GenerateToken(keys, time.Hour)(&us.user, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	swagger, err := openapi.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger.Servers = openapi3.Servers{{URL: "/api/v1"}}
+
+	s := &server{
+		e:         echo.New(),
+		srv:       srv,
+		userToken: userToken,
+	}
+
+	s.e.GET("/oauth/authorize", transport.Authorize)
+	s.e.POST("/oauth/token", transport.Token)
+
+	apiGroup := s.e.Group("/api/v1")
+	apiGroup.Use(security.ValidationMiddleware(swagger, validator, lookup, security.NewCookieAuth(lookup, cfg), noAPIKeys{}))
+	openapi.RegisterHandlersWithBaseURL(apiGroup, &openapi.ServerInterfaceMock{
+		GetOauthConsentFunc:   transport.GetOauthConsent,
+		GrantOauthConsentFunc: transport.GrantOauthConsent,
+		GetUserinfoFunc:       transport.GetUserinfo,
+		ListOauthClientsFunc:  transport.ListOauthClients,
+		CreateApiKeyFunc: func(c echo.Context) error {
+			s.createdAPIKey = true
+			return c.NoContent(http.StatusCreated)
+		},
+	}, "")
+
+	return s
+}
+
+func (s *server) do(req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	s.e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// authorize runs a client through the authorization endpoint and the consent
+// of the user and returns the code it is sent back with
+func (s *server) authorize(t *testing.T, clientID, scope, challenge string) string {
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {scope},
+		"state":                 {"xyz"},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	rec := s.do(httptest.NewRequest(http.MethodGet, "/oauth/authorize?"+q.Encode(), nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("authorize: got %d %s", rec.Code, rec.Body)
+	}
+	if loc := rec.Header().Get(echo.HeaderLocation); !strings.HasPrefix(loc, consentURL+"?") {
+		t.Fatalf("authorize: redirected to %q, want the consent page", loc)
+	}
+
+	body, _ := json.Marshal(openapi.OauthConsentRequest{
+		ResponseType:        "code",
+		ClientId:            clientID,
+		RedirectUri:         redirectURI,
+		Scope:               &scope,
+		State:               optional("xyz"),
+		CodeChallenge:       challenge,
+		CodeChallengeMethod: "S256",
+		Approve:             true,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/oauth/consent", strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+s.userToken)
+
+	rec = s.do(req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("consent: got %d %s", rec.Code, rec.Body)
+	}
+
+	var to openapi.OauthRedirect
+	if err := json.Unmarshal(rec.Body.Bytes(), &to); err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(to.RedirectTo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(to.RedirectTo, redirectURI+"?") || u.Query().Get("state") != "xyz" {
+		t.Fatalf("consent: redirect to %q", to.RedirectTo)
+	}
+
+	code := u.Query().Get("code")
+	if code == "" {
+		t.Fatalf("consent: no code in %q", to.RedirectTo)
+	}
+
+	return code
+}
+
+// token posts a form to the token endpoint
+func (s *server) token(form url.Values, clientID, clientSecret string) (int, map[string]interface{}) {
+	req := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	if clientSecret != "" {
+		req.SetBasicAuth(clientID, clientSecret)
+	}
+
+	rec := s.do(req)
+
+	body := map[string]interface{}{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+
+	return rec.Code, body
+}
+
+// exchange exchanges a code of a public client
+func (s *server) exchange(clientID, code, verifier string) (int, map[string]interface{}) {
+	return s.token(url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {clientID},
+		"code_verifier": {verifier},
+	}, clientID, "")
+}
+
+// call calls the API with an access token
+func (s *server) call(method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+
+	return s.do(req)
+}
+
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+const (
+	verifier      = "dBjftJeZ4CVP-mJ92ZoDKXNIeHOKk8Ruv7wcT6ZrIXVjvLUodD5xJc9NtiaNmXUd"
+	otherVerifier = "xBjftJeZ4CVP-mJ92ZoDKXNIeHOKk8Ruv7wcT6ZrIXVjvLUodD5xJc9NtiaNmXUd"
+)
+
+func TestAuthorizationCode(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	c, _, err := s.srv.CreateClient(ctx, "app", []string{redirectURI}, []string{ScopeOpenID, "admin"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("pkce", func(t *testing.T) {
+		code := s.authorize(t, c.ClientID, "openid", challenge(verifier))
+
+		status, body := s.exchange(c.ClientID, code, verifier)
+		if status != http.StatusOK {
+			t.Fatalf("got %d %v", status, body)
+		}
+		if body["token_type"] != "Bearer" || body["scope"] != "openid" || body["access_token"] == nil || body["id_token"] == nil {
+			t.Fatalf("unexpected token response %v", body)
+		}
+
+		rec := s.call(http.MethodGet, "/api/v1/userinfo", "", body["access_token"].(string))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"sub":"1"`) {
+			t.Fatalf("userinfo: got %d %s", rec.Code, rec.Body)
+		}
+	})
+
+	t.Run("verifier mismatch", func(t *testing.T) {
+		code := s.authorize(t, c.ClientID, "openid", challenge(verifier))
+
+		status, body := s.exchange(c.ClientID, code, otherVerifier)
+		if status != http.StatusBadRequest || body["error"] != CodeInvalidGrant {
+			t.Fatalf("got %d %v, want invalid_grant", status, body)
+		}
+	})
+
+	t.Run("code reuse", func(t *testing.T) {
+		code := s.authorize(t, c.ClientID, "openid", challenge(verifier))
+
+		status, body := s.exchange(c.ClientID, code, verifier)
+		if status != http.StatusOK {
+			t.Fatalf("first exchange: got %d %v", status, body)
+		}
+
+		status, body = s.exchange(c.ClientID, code, verifier)
+		if status != http.StatusBadRequest || body["error"] != CodeInvalidGrant {
+			t.Fatalf("second exchange: got %d %v, want invalid_grant", status, body)
+		}
+	})
+}
+
+func TestClientCredentials(t *testing.T) {
+	s := newServer(t)
+
+	c, clientSecret, err := s.srv.CreateClient(context.Background(), "worker", nil, []string{"admin"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+
+	status, body := s.token(form, c.ClientID, clientSecret)
+	if status != http.StatusOK || body["scope"] != "admin" || body["access_token"] == nil {
+		t.Fatalf("got %d %v", status, body)
+	}
+
+	status, body = s.token(form, c.ClientID, "wrong")
+	if status != http.StatusUnauthorized || body["error"] != CodeInvalidClient {
+		t.Fatalf("wrong secret: got %d %v, want invalid_client", status, body)
+	}
+
+	form.Set("scope", "openid")
+	status, body = s.token(form, c.ClientID, clientSecret)
+	if status != http.StatusBadRequest || body["error"] != CodeInvalidScope {
+		t.Fatalf("scope of another client: got %d %v, want invalid_scope", status, body)
+	}
+}
+
+func TestClientTokenOnFirstPartyOperation(t *testing.T) {
+	s := newServer(t)
+
+	c, _, err := s.srv.CreateClient(context.Background(), "app", []string{redirectURI}, []string{ScopeOpenID, "admin"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// even granted every scope of the user, a client may not mint API keys
+	code := s.authorize(t, c.ClientID, "openid admin", challenge(verifier))
+	status, body := s.exchange(c.ClientID, code, verifier)
+	if status != http.StatusOK {
+		t.Fatalf("got %d %v", status, body)
+	}
+
+	apiKey := `{"name":"escalate","scopes":["admin"]}`
+
+	rec := s.call(http.MethodPost, "/api/v1/user/api-keys", apiKey, body["access_token"].(string))
+	if rec.Code != http.StatusForbidden || s.createdAPIKey {
+		t.Fatalf("client token: got %d %s, want 403", rec.Code, rec.Body)
+	}
+
+	rec = s.call(http.MethodPost, "/api/v1/user/api-keys", apiKey, s.userToken)
+	if rec.Code != http.StatusCreated || !s.createdAPIKey {
+		t.Fatalf("user token: got %d %s, want 201", rec.Code, rec.Body)
+	}
+}
+
+func TestDeletedClientTokens(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	c, clientSecret, err := s.srv.CreateClient(ctx, "worker", nil, []string{"admin"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, body := s.token(url.Values{"grant_type": {"client_credentials"}}, c.ClientID, clientSecret)
+	if status != http.StatusOK {
+		t.Fatalf("got %d %v", status, body)
+	}
+	token := body["access_token"].(string)
+
+	rec := s.call(http.MethodGet, "/api/v1/admin/oauth/clients", "", token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("before delete: got %d %s", rec.Code, rec.Body)
+	}
+
+	err = s.srv.DeleteClient(ctx, c.ClientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec = s.call(http.MethodGet, "/api/v1/admin/oauth/clients", "", token)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("after delete: got %d %s, want 401", rec.Code, rec.Body)
+	}
+}
+
+func TestRevokedConsentTokens(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	c, _, err := s.srv.CreateClient(ctx, "app", []string{redirectURI}, []string{ScopeOpenID}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code := s.authorize(t, c.ClientID, "openid", challenge(verifier))
+	status, body := s.exchange(c.ClientID, code, verifier)
+	if status != http.StatusOK {
+		t.Fatalf("got %d %v", status, body)
+	}
+	token := body["access_token"].(string)
+
+	rec := s.call(http.MethodGet, "/api/v1/userinfo", "", token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("before revoke: got %d %s", rec.Code, rec.Body)
+	}
+
+	err = s.srv.RevokeConsent(ctx, 1, c.ClientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec = s.call(http.MethodGet, "/api/v1/userinfo", "", token)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("after revoke: got %d %s, want 401", rec.Code, rec.Body)
+	}
+}
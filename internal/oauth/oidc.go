@@ -0,0 +1,113 @@
+package oauth
+
+import (
+	"go-api-template/internal/security"
+	"go-api-template/internal/user"
+	"net/http"
+	"strconv"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo/v4"
+)
+
+// OpenID Connect scopes (OIDC Core 5.4). They select claims, they are not
+// permissions.
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// IDTokenClaims are the claims of an ID token (OIDC Core 2)
+type IDTokenClaims struct {
+	Nonce string `json:"nonce,omitempty"`
+	Profile
+
+	jwt.StandardClaims
+}
+
+// UserInfo holds the standard claims about a user (OIDC Core 5.1) released
+// for the granted scopes
+type UserInfo struct {
+	Subject string `json:"sub"`
+	Profile
+}
+
+// Profile holds the claims of UserInfo other than the subject, the subject
+// of an ID token is its standard sub claim
+type Profile struct {
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+	Name          string `json:"name,omitempty"`
+	GivenName     string `json:"given_name,omitempty"`
+	FamilyName    string `json:"family_name,omitempty"`
+	Picture       string `json:"picture,omitempty"`
+}
+
+// userInfo returns the claims about u scopes release, imageURL is the url of
+// the avatar of u
+func userInfo(u *user.User, imageURL string, scopes []string) UserInfo {
+	info := UserInfo{
+		Subject: strconv.Itoa(u.ID),
+	}
+
+	for _, s := range scopes {
+		switch s {
+		case ScopeEmail:
+			verified := u.EmailVerifiedAt != nil
+			info.Email = u.Email
+			info.EmailVerified = &verified
+		case ScopeProfile:
+			info.GivenName = u.FirstName
+			info.FamilyName = u.LastName
+			info.Name = u.FirstName
+			if u.LastName != "" {
+				info.Name += " " + u.LastName
+			}
+			info.Picture = imageURL
+		}
+	}
+
+	return info
+}
+
+// Discovery is the OpenID Provider metadata (OIDC Discovery 3)
+type Discovery struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// DiscoveryHandler serves the OpenID Provider metadata of issuer
+func DiscoveryHandler(issuer string, keys *security.Keyring) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Header().Set("Cache-Control", "public, max-age=300")
+		return c.JSON(http.StatusOK, Discovery{
+			Issuer:                            issuer,
+			AuthorizationEndpoint:             issuer + "/oauth/authorize",
+			TokenEndpoint:                     issuer + "/oauth/token",
+			UserinfoEndpoint:                  issuer + "/api/v1/userinfo",
+			IntrospectionEndpoint:             issuer + "/oauth/introspect",
+			JWKSURI:                           issuer + "/.well-known/jwks.json",
+			ScopesSupported:                   []string{ScopeOpenID, ScopeProfile, ScopeEmail},
+			ResponseTypesSupported:            []string{"code"},
+			GrantTypesSupported:               []string{"authorization_code", "client_credentials"},
+			SubjectTypesSupported:             []string{"public"},
+			IDTokenSigningAlgValuesSupported:  []string{keys.KeySet().Algorithm()},
+			TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+			CodeChallengeMethodsSupported:     []string{pkceMethodS256},
+			ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "nonce", "email", "email_verified", "name", "given_name", "family_name", "picture"},
+		})
+	}
+}
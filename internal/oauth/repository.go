@@ -0,0 +1,203 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/rs/zerolog"
+)
+
+// Repository is data provider
+type Repository interface {
+	CreateClient(ctx context.Context, c *Client) (*Client, error)
+	FindClient(ctx context.Context, clientID string) (*Client, error)
+	ListClients(ctx context.Context) ([]Client, error)
+	DeleteClient(ctx context.Context, clientID string) error
+	CreateCode(ctx context.Context, code *AuthorizationCode) error
+	ConsumeCode(ctx context.Context, hash string) (*AuthorizationCode, error)
+	FindConsent(ctx context.Context, userID int, clientID string) (*Consent, error)
+	SaveConsent(ctx context.Context, consent *Consent) error
+	ListConsents(ctx context.Context, userID int) ([]Consent, error)
+	DeleteConsent(ctx context.Context, userID int, clientID string) error
+}
+
+var (
+	errRepoClientNotFound      = errors.New("oauth client not found")
+	errRepoClientAlreadyExists = errors.New("oauth client already exists")
+	errRepoCodeNotFound        = errors.New("authorization code not found")
+	errRepoConsentNotFound     = errors.New("consent not found")
+)
+
+type repo struct {
+	logger zerolog.Logger
+	db     *pg.DB
+}
+
+func (r repo) CreateClient(ctx context.Context, c *Client) (*Client, error) {
+	_, err := r.db.ModelContext(ctx, c).Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		pgErr, ok := err.(pg.Error)
+		if ok && pgErr.IntegrityViolation() {
+			return nil, errRepoClientAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (r repo) FindClient(ctx context.Context, clientID string) (*Client, error) {
+	c := &Client{}
+
+	err := r.db.ModelContext(ctx, c).Where("client_id = ?", clientID).First()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, errRepoClientNotFound
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (r repo) ListClients(ctx context.Context) ([]Client, error) {
+	clients := []Client{}
+
+	err := r.db.ModelContext(ctx, &clients).Order("id ASC").Select()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// DeleteClient deletes a client along with its codes and consents
+func (r repo) DeleteClient(ctx context.Context, clientID string) error {
+	res, err := r.db.ModelContext(ctx, (*Client)(nil)).
+		Where("client_id = ?", clientID).
+		Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoClientNotFound
+	}
+
+	return nil
+}
+
+func (r repo) CreateCode(ctx context.Context, code *AuthorizationCode) error {
+	_, err := r.db.ModelContext(ctx, code).Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// ConsumeCode deletes an unexpired code and returns it, so a code can only
+// ever be exchanged once
+func (r repo) ConsumeCode(ctx context.Context, hash string) (*AuthorizationCode, error) {
+	code := &AuthorizationCode{}
+
+	res, err := r.db.ModelContext(ctx, code).
+		Where("code_hash = ?", hash).
+		Where("expires_at > ?", time.Now()).
+		Returning("*").
+		Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	if res.RowsAffected() == 0 {
+		return nil, errRepoCodeNotFound
+	}
+
+	return code, nil
+}
+
+func (r repo) FindConsent(ctx context.Context, userID int, clientID string) (*Consent, error) {
+	consent := &Consent{}
+
+	err := r.db.ModelContext(ctx, consent).
+		Where("user_id = ?", userID).
+		Where("client_id = ?", clientID).
+		First()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, errRepoConsentNotFound
+		}
+		return nil, err
+	}
+
+	return consent, nil
+}
+
+// SaveConsent stores a consent, replacing the scopes of a previous one
+func (r repo) SaveConsent(ctx context.Context, consent *Consent) error {
+	_, err := r.db.ModelContext(ctx, consent).
+		OnConflict("(user_id, client_id) DO UPDATE").
+		Set("scopes = EXCLUDED.scopes").
+		Set("updated_at = EXCLUDED.updated_at").
+		Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+func (r repo) ListConsents(ctx context.Context, userID int) ([]Consent, error) {
+	consents := []Consent{}
+
+	err := r.db.ModelContext(ctx, &consents).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Select()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	return consents, nil
+}
+
+func (r repo) DeleteConsent(ctx context.Context, userID int, clientID string) error {
+	res, err := r.db.ModelContext(ctx, (*Consent)(nil)).
+		Where("user_id = ?", userID).
+		Where("client_id = ?", clientID).
+		Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoConsentNotFound
+	}
+
+	return nil
+}
+
+// NewRepository creates a new repository
+func NewRepository(
+	logger zerolog.Logger,
+	db *pg.DB,
+) Repository {
+	return &repo{
+		logger: logger,
+		db:     db,
+	}
+}
@@ -0,0 +1,706 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"go-api-template/internal/config"
+	"go-api-template/internal/security"
+	"go-api-template/internal/user"
+	"go-api-template/pkg/secret"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+// Service is a service provider
+type Service interface {
+	CreateClient(ctx context.Context, name string, redirectURIs []string, scopes []string, confidential bool) (*Client, string, error)
+	ListClients(ctx context.Context) ([]Client, error)
+	DeleteClient(ctx context.Context, clientID string) error
+	ValidateAuthorization(ctx context.Context, req *AuthorizationRequest) (*Client, []string, error)
+	Consented(ctx context.Context, userID int, req *AuthorizationRequest) (*Client, []string, bool, error)
+	Authorize(ctx context.Context, u *user.User, req *AuthorizationRequest, approve bool) (string, error)
+	Exchange(ctx context.Context, clientID, clientSecret, code, redirectURI, codeVerifier string) (*Token, error)
+	ClientCredentials(ctx context.Context, clientID, clientSecret, scope string) (*Token, error)
+	ListConsents(ctx context.Context, userID int) ([]Consent, error)
+	RevokeConsent(ctx context.Context, userID int, clientID string) error
+	UserInfo(ctx context.Context, userID int, scopes []string) (*UserInfo, error)
+	Introspect(ctx context.Context, clientID, clientSecret, token string) (*Introspection, error)
+}
+
+// Errors that can occur in the service
+var (
+	ErrInternalService   = errors.New("internal service error")
+	ErrClientNotFound    = errors.New("oauth client not found")
+	ErrConsentNotFound   = errors.New("consent not found")
+	ErrInvalidClientReq  = errors.New("a client needs a name and redirect uris or a secret")
+	ErrInsufficientScope = errors.New("the token was not granted the openid scope")
+)
+
+// OAuth error codes (RFC 6749 4.1.2.1 and 5.2)
+const (
+	CodeInvalidRequest          = "invalid_request"
+	CodeInvalidClient           = "invalid_client"
+	CodeInvalidGrant            = "invalid_grant"
+	CodeUnauthorizedClient      = "unauthorized_client"
+	CodeUnsupportedGrantType    = "unsupported_grant_type"
+	CodeUnsupportedResponseType = "unsupported_response_type"
+	CodeInvalidScope            = "invalid_scope"
+	CodeAccessDenied            = "access_denied"
+	CodeServerError             = "server_error"
+)
+
+// Error is an error reported to clients in the format of RFC 6749
+type Error struct {
+	Code        string
+	Description string
+
+	// redirect is set for errors that may be sent to the redirect URI of
+	// an authorization request, errors about the client itself never are
+	redirect bool
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+// Redirectable reports whether the error may be sent to the client through
+// the redirect URI of the request
+func (e *Error) Redirectable() bool {
+	return e.redirect
+}
+
+func newError(code, description string) *Error {
+	return &Error{
+		Code:        code,
+		Description: description,
+	}
+}
+
+func newRedirectError(code, description string) *Error {
+	return &Error{
+		Code:        code,
+		Description: description,
+		redirect:    true,
+	}
+}
+
+var errServer = newError(CodeServerError, "internal server error")
+
+// PKCE (RFC 7636), only the S256 method is supported
+const (
+	pkceMethodS256     = "S256"
+	pkceVerifierMinLen = 43
+	pkceVerifierMaxLen = 128
+)
+
+type service struct {
+	logger         zerolog.Logger
+	repo           Repository
+	users          user.Service
+	sign           func(claims jwt.Claims) (string, error)
+	validate       func(ctx context.Context, token string) (*security.JwtClaims, error)
+	issuer         string
+	codeTTL        time.Duration
+	accessTokenTTL time.Duration
+}
+
+// CreateClient registers a client. Confidential clients get a secret which is
+// returned once and never stored.
+func (s service) CreateClient(ctx context.Context, name string, redirectURIs []string, scopes []string, confidential bool) (*Client, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || (len(redirectURIs) == 0 && !confidential) {
+		return nil, "", ErrInvalidClientReq
+	}
+
+	for _, uri := range redirectURIs {
+		u, err := url.Parse(uri)
+		if err != nil || !u.IsAbs() || u.Fragment != "" {
+			return nil, "", ErrInvalidClientReq
+		}
+	}
+
+	clientID, err := secret.Token(16)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, "", ErrInternalService
+	}
+
+	c := &Client{
+		ClientID:     clientID,
+		Name:         name,
+		RedirectURIs: redirectURIs,
+		Scopes:       unique(scopes),
+	}
+
+	clientSecret := ""
+	if confidential {
+		clientSecret, err = secret.Token(secret.DefaultSize)
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("")
+			return nil, "", ErrInternalService
+		}
+		c.SecretHash = secret.Hash(clientSecret)
+	}
+
+	c, err = s.repo.CreateClient(ctx, c)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, "", ErrInternalService
+	}
+
+	return c, clientSecret, nil
+}
+
+func (s service) ListClients(ctx context.Context) ([]Client, error) {
+	clients, err := s.repo.ListClients(ctx)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	return clients, nil
+}
+
+// DeleteClient removes a client, the tokens issued to it stop working
+func (s service) DeleteClient(ctx context.Context, clientID string) error {
+	err := s.repo.DeleteClient(ctx, clientID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoClientNotFound) {
+			return ErrClientNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// ValidateAuthorization checks an authorization request and returns its
+// client and the scopes it asks for. Errors are of type *Error.
+func (s service) ValidateAuthorization(ctx context.Context, req *AuthorizationRequest) (*Client, []string, error) {
+	c, err := s.repo.FindClient(ctx, req.ClientID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoClientNotFound) {
+			return nil, nil, newError(CodeInvalidClient, "unknown client")
+		}
+
+		return nil, nil, errServer
+	}
+
+	if !c.AllowsRedirect(req.RedirectURI) {
+		return nil, nil, newError(CodeInvalidRequest, "redirect_uri is not registered for the client")
+	}
+
+	if req.ResponseType != "code" {
+		return nil, nil, newRedirectError(CodeUnsupportedResponseType, "only the code response type is supported")
+	}
+
+	if req.CodeChallenge == "" || req.CodeChallengeMethod != pkceMethodS256 {
+		return nil, nil, newRedirectError(CodeInvalidRequest, "a S256 code_challenge is required")
+	}
+
+	scopes, oerr := clientScopes(c, req.Scope)
+	if oerr != nil {
+		oerr.redirect = true
+		return nil, nil, oerr
+	}
+
+	return c, scopes, nil
+}
+
+// Consented validates an authorization request and reports whether the user
+// already granted its scopes to the client
+func (s service) Consented(ctx context.Context, userID int, req *AuthorizationRequest) (*Client, []string, bool, error) {
+	c, scopes, err := s.ValidateAuthorization(ctx, req)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	consent, err := s.repo.FindConsent(ctx, userID, c.ClientID)
+	if err != nil && !errors.Is(err, errRepoConsentNotFound) {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, nil, false, errServer
+	}
+
+	return c, scopes, consent.Covers(scopes), nil
+}
+
+// Authorize answers an authorization request on behalf of u and returns the
+// URL to send the user back to the client with, carrying either a code or
+// the refusal
+func (s service) Authorize(ctx context.Context, u *user.User, req *AuthorizationRequest, approve bool) (string, error) {
+	c, scopes, err := s.ValidateAuthorization(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	if !approve {
+		return ErrorRedirect(req, newRedirectError(CodeAccessDenied, "the user denied the request")), nil
+	}
+
+	consent, err := s.repo.FindConsent(ctx, u.ID, c.ClientID)
+	if err != nil && !errors.Is(err, errRepoConsentNotFound) {
+		s.logger.Debug().Err(err).Msg("")
+		return "", errServer
+	}
+
+	if !consent.Covers(scopes) {
+		granted := scopes
+		if consent != nil {
+			granted = unique(append(consent.Scopes, scopes...))
+		}
+
+		err = s.repo.SaveConsent(ctx, &Consent{
+			UserID:   u.ID,
+			ClientID: c.ClientID,
+			Scopes:   granted,
+		})
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("")
+			return "", errServer
+		}
+	}
+
+	code, err := secret.Token(secret.DefaultSize)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return "", errServer
+	}
+
+	err = s.repo.CreateCode(ctx, &AuthorizationCode{
+		CodeHash:      secret.Hash(code),
+		ClientID:      c.ClientID,
+		UserID:        u.ID,
+		RedirectURI:   req.RedirectURI,
+		Scopes:        scopes,
+		CodeChallenge: req.CodeChallenge,
+		Nonce:         req.Nonce,
+		ExpiresAt:     time.Now().Add(s.codeTTL),
+	})
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return "", errServer
+	}
+
+	return redirect(req.RedirectURI, req.State, url.Values{"code": {code}}), nil
+}
+
+// Exchange exchanges an authorization code for an access token (RFC 6749
+// 4.1.3) after checking the PKCE verifier. Errors are of type *Error.
+func (s service) Exchange(ctx context.Context, clientID, clientSecret, code, redirectURI, codeVerifier string) (*Token, error) {
+	c, oerr := s.authenticateClient(ctx, clientID, clientSecret)
+	if oerr != nil {
+		return nil, oerr
+	}
+
+	ac, err := s.repo.ConsumeCode(ctx, secret.Hash(code))
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoCodeNotFound) {
+			return nil, newError(CodeInvalidGrant, "invalid or expired code")
+		}
+
+		return nil, errServer
+	}
+
+	if ac.ClientID != c.ClientID || ac.RedirectURI != redirectURI {
+		return nil, newError(CodeInvalidGrant, "code was issued to another client or redirect_uri")
+	}
+
+	if !verifyPKCE(ac.CodeChallenge, codeVerifier) {
+		return nil, newError(CodeInvalidGrant, "invalid code_verifier")
+	}
+
+	u, err := s.users.FindByID(ctx, ac.UserID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, newError(CodeInvalidGrant, "the user no longer exists")
+		}
+
+		return nil, errServer
+	}
+
+	if !u.Active {
+		return nil, newError(CodeInvalidGrant, "the user is suspended")
+	}
+
+	// scopes only grant the permissions the user still has
+	t, err := s.issue(&security.JwtClaims{
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		UserID:      u.ID,
+		Permissions: intersect(ac.Scopes, u.Permissions()),
+		ClientID:    c.ClientID,
+	}, ac.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasScope(ac.Scopes, ScopeOpenID) {
+		t.IDToken, err = s.idToken(u, c, ac)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// idToken returns the signed ID token (OIDC Core 3.1.3.3) of an exchanged code
+func (s service) idToken(u *user.User, c *Client, ac *AuthorizationCode) (string, error) {
+	now := time.Now()
+
+	t, err := s.sign(&IDTokenClaims{
+		Nonce:   ac.Nonce,
+		Profile: userInfo(u, s.users.ImageURL(u), ac.Scopes).Profile,
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    s.issuer,
+			Subject:   strconv.Itoa(u.ID),
+			Audience:  c.ClientID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(s.accessTokenTTL).Unix(),
+		},
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Msg("sign id token")
+		return "", errServer
+	}
+
+	return t, nil
+}
+
+// UserInfo returns the claims about a user the scopes of an access token
+// release (OIDC Core 5.3)
+func (s service) UserInfo(ctx context.Context, userID int, scopes []string) (*UserInfo, error) {
+	if !hasScope(scopes, ScopeOpenID) {
+		return nil, ErrInsufficientScope
+	}
+
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := userInfo(u, s.users.ImageURL(u), scopes)
+
+	return &info, nil
+}
+
+// ClientCredentials issues a token to a confidential client acting on its own
+// behalf (RFC 6749 4.4). Errors are of type *Error.
+func (s service) ClientCredentials(ctx context.Context, clientID, clientSecret, scope string) (*Token, error) {
+	c, oerr := s.authenticateClient(ctx, clientID, clientSecret)
+	if oerr != nil {
+		return nil, oerr
+	}
+
+	if !c.Confidential() {
+		return nil, newError(CodeUnauthorizedClient, "public clients can not use client credentials")
+	}
+
+	scopes, oerr := clientScopes(c, scope)
+	if oerr != nil {
+		return nil, oerr
+	}
+
+	return s.issue(&security.JwtClaims{
+		Permissions: scopes,
+		ClientID:    c.ClientID,
+		StandardClaims: jwt.StandardClaims{
+			Subject: c.ClientID,
+		},
+	}, scopes)
+}
+
+// Introspect reports whether a token is active (RFC 7662). Only confidential
+// clients may introspect, a token is checked the way requests to the API are.
+func (s service) Introspect(ctx context.Context, clientID, clientSecret, token string) (*Introspection, error) {
+	c, oerr := s.authenticateClient(ctx, clientID, clientSecret)
+	if oerr != nil {
+		return nil, oerr
+	}
+	if !c.Confidential() {
+		return nil, newError(CodeInvalidClient, "only confidential clients may introspect tokens")
+	}
+
+	if token == "" {
+		return nil, newError(CodeInvalidRequest, "token is required")
+	}
+
+	claims, err := s.validate(ctx, token)
+	if err != nil {
+		var he *echo.HTTPError
+		if errors.As(err, &he) && he.Code == http.StatusInternalServerError {
+			s.logger.Error().Err(err).Msg("introspect")
+			return nil, errServer
+		}
+
+		s.logger.Debug().Err(err).Msg("")
+		return &Introspection{}, nil
+	}
+
+	i := &Introspection{
+		Active:    true,
+		ClientID:  claims.ClientID,
+		ExpiresAt: claims.ExpiresAt,
+		IssuedAt:  claims.IssuedAt,
+	}
+	if claims.UserID != 0 {
+		i.Subject = strconv.Itoa(claims.UserID)
+	}
+
+	// first party tokens carry no scope, their permissions are what they grant
+	i.Scopes = strings.Fields(claims.Scope)
+	if claims.Scope == "" {
+		i.Scopes = claims.Permissions
+	}
+
+	return i, nil
+}
+
+func (s service) ListConsents(ctx context.Context, userID int) ([]Consent, error) {
+	consents, err := s.repo.ListConsents(ctx, userID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	return consents, nil
+}
+
+// RevokeConsent forgets the scopes a user granted to a client, the user is
+// asked again on the next authorization request. Tokens already issued to the
+// client for the user stop working.
+func (s service) RevokeConsent(ctx context.Context, userID int, clientID string) error {
+	err := s.repo.DeleteConsent(ctx, userID, clientID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoConsentNotFound) {
+			return ErrConsentNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// authenticateClient checks the credentials of a client, public clients have
+// no secret to check
+func (s service) authenticateClient(ctx context.Context, clientID, clientSecret string) (*Client, *Error) {
+	c, err := s.repo.FindClient(ctx, clientID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoClientNotFound) {
+			return nil, newError(CodeInvalidClient, "client authentication failed")
+		}
+
+		return nil, errServer
+	}
+
+	if !c.Confidential() {
+		if clientSecret != "" {
+			return nil, newError(CodeInvalidClient, "client authentication failed")
+		}
+
+		return c, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(secret.Hash(clientSecret)), []byte(c.SecretHash)) != 1 {
+		return nil, newError(CodeInvalidClient, "client authentication failed")
+	}
+
+	return c, nil
+}
+
+// issue completes claims and signs them
+func (s service) issue(claims *security.JwtClaims, scopes []string) (*Token, error) {
+	now := time.Now()
+
+	claims.Scope = strings.Join(scopes, " ")
+	claims.Id = uuid.New().String()
+	claims.SetIssuedAt(now)
+	claims.ExpiresAt = now.Add(s.accessTokenTTL).Unix()
+
+	t, err := s.sign(claims)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("sign oauth token")
+		return nil, errServer
+	}
+
+	return &Token{
+		AccessToken: t,
+		ExpiresIn:   int(s.accessTokenTTL.Seconds()),
+		Scopes:      scopes,
+	}, nil
+}
+
+// clientScopes parses a space delimited scope parameter, all of the client's
+// scopes when it is empty
+func clientScopes(c *Client, scope string) ([]string, *Error) {
+	requested := unique(strings.Fields(scope))
+	if len(requested) == 0 {
+		return c.Scopes, nil
+	}
+
+	if len(intersect(requested, c.Scopes)) != len(requested) {
+		return nil, newError(CodeInvalidScope, "scope exceeds the scopes of the client")
+	}
+
+	return requested, nil
+}
+
+// verifyPKCE checks a code verifier against the S256 challenge of a code
+func verifyPKCE(challenge, verifier string) bool {
+	if len(verifier) < pkceVerifierMinLen || len(verifier) > pkceVerifierMaxLen {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// ErrorRedirect returns the URL sending an error back to the client of an
+// authorization request
+func ErrorRedirect(req *AuthorizationRequest, err *Error) string {
+	return redirect(req.RedirectURI, req.State, url.Values{
+		"error":             {err.Code},
+		"error_description": {err.Description},
+	})
+}
+
+// redirect adds params and state to the query of uri
+func redirect(uri, state string, params url.Values) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	q := u.Query()
+	for k, v := range params {
+		q[k] = v
+	}
+	if state != "" {
+		q.Set("state", state)
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+
+	return out
+}
+
+// intersect returns the values found in allowed
+func intersect(values []string, allowed []string) []string {
+	granted := make(map[string]bool, len(allowed))
+	for _, a := range allowed {
+		granted[a] = true
+	}
+
+	out := []string{}
+	for _, v := range unique(values) {
+		if granted[v] {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// grants checks that the client a token was issued to still exists and, for
+// a token of a user, that the user did not revoke the consent
+type grants struct {
+	logger zerolog.Logger
+	repo   Repository
+}
+
+func (g grants) IsGranted(ctx context.Context, claims *security.JwtClaims) (bool, error) {
+	_, err := g.repo.FindClient(ctx, claims.ClientID)
+	if err != nil {
+		if errors.Is(err, errRepoClientNotFound) {
+			return false, nil
+		}
+
+		g.logger.Debug().Err(err).Msg("")
+		return false, ErrInternalService
+	}
+
+	if claims.UserID == 0 {
+		return true, nil
+	}
+
+	consent, err := g.repo.FindConsent(ctx, claims.UserID, claims.ClientID)
+	if err != nil && !errors.Is(err, errRepoConsentNotFound) {
+		g.logger.Debug().Err(err).Msg("")
+		return false, ErrInternalService
+	}
+
+	return consent.Covers(strings.Fields(claims.Scope)), nil
+}
+
+// NewGrantChecker creates the checker the validator asks whether tokens
+// issued to clients are still granted
+func NewGrantChecker(logger zerolog.Logger, repo Repository) security.GrantChecker {
+	return &grants{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+// NewService creates a new service
+func NewService(
+	logger zerolog.Logger,
+	repo Repository,
+	users user.Service,
+	sign func(claims jwt.Claims) (string, error),
+	validate func(ctx context.Context, token string) (*security.JwtClaims, error),
+	cfg *config.Config,
+) Service {
+	return &service{
+		logger:         logger,
+		repo:           repo,
+		users:          users,
+		sign:           sign,
+		validate:       validate,
+		issuer:         cfg.OAuth.Issuer,
+		codeTTL:        cfg.OAuth.CodeTTL,
+		accessTokenTTL: cfg.OAuth.AccessTokenTTL,
+	}
+}
@@ -0,0 +1,484 @@
+package oauth
+
+import (
+	"errors"
+	"go-api-template/internal/openapi"
+	"go-api-template/internal/security"
+	"go-api-template/internal/user"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+// Transport handles transport for service
+type Transport struct {
+	logger        zerolog.Logger
+	srv           Service
+	users         user.Service
+	currentClaims func(c echo.Context) *security.JwtClaims
+	consentURL    string
+}
+
+// NewTransport creates a new transport. Authorization requests are forwarded
+// to consentURL, the page of the app that logs the user in and asks for
+// consent.
+func NewTransport(
+	logger zerolog.Logger,
+	srv Service,
+	users user.Service,
+	currentClaims func(c echo.Context) *security.JwtClaims,
+	consentURL string,
+) Transport {
+	return Transport{
+		logger:        logger,
+		srv:           srv,
+		users:         users,
+		currentClaims: currentClaims,
+		consentURL:    consentURL,
+	}
+}
+
+// tokenResponse is the successful response of the token endpoint (RFC 6749 5.1)
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+	IDToken     string `json:"id_token,omitempty"`
+}
+
+// introspectionResponse is the response of the introspection endpoint (RFC 7662 2.2)
+type introspectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+}
+
+// errorResponse is the error response of the OAuth endpoints (RFC 6749 5.2)
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// Authorize is the authorization endpoint (RFC 6749 3.1). A valid request is
+// forwarded to the consent page of the app, errors go back to the client when
+// its redirect URI can be trusted.
+func (h Transport) Authorize(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := authorizationRequest(c.QueryParams())
+
+	_, _, err := h.srv.ValidateAuthorization(ctx, req)
+	if err != nil {
+		return h.authorizeError(c, req, err)
+	}
+
+	u, err := url.Parse(h.consentURL)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	u.RawQuery = c.QueryString()
+
+	return c.Redirect(http.StatusFound, u.String())
+}
+
+func (h Transport) authorizeError(c echo.Context, req *AuthorizationRequest, err error) error {
+	h.logger.Err(err).Msg("")
+
+	var oerr *Error
+	if !errors.As(err, &oerr) {
+		oerr = errServer
+	}
+
+	if oerr.Redirectable() {
+		return c.Redirect(http.StatusFound, ErrorRedirect(req, oerr))
+	}
+
+	return c.JSON(http.StatusBadRequest, errorResponse{
+		Error:            oerr.Code,
+		ErrorDescription: oerr.Description,
+	})
+}
+
+// Token is the token endpoint (RFC 6749 3.2). It takes form encoded requests
+// for the authorization_code and client_credentials grants. Clients
+// authenticate with HTTP Basic or with client_id and client_secret in the
+// form.
+func (h Transport) Token(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Pragma", "no-cache")
+
+	clientID, clientSecret := clientCredentials(c)
+
+	var (
+		t   *Token
+		err error
+	)
+	switch c.FormValue("grant_type") {
+	case "authorization_code":
+		t, err = h.srv.Exchange(ctx, clientID, clientSecret, c.FormValue("code"), c.FormValue("redirect_uri"), c.FormValue("code_verifier"))
+	case "client_credentials":
+		t, err = h.srv.ClientCredentials(ctx, clientID, clientSecret, c.FormValue("scope"))
+	case "":
+		err = newError(CodeInvalidRequest, "grant_type is required")
+	default:
+		err = newError(CodeUnsupportedGrantType, "only authorization_code and client_credentials are supported")
+	}
+	if err != nil {
+		return h.tokenError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, tokenResponse{
+		AccessToken: t.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   t.ExpiresIn,
+		Scope:       strings.Join(t.Scopes, " "),
+		IDToken:     t.IDToken,
+	})
+}
+
+// Introspect is the introspection endpoint (RFC 7662). Resource servers
+// authenticate as confidential clients and learn whether a token is active.
+func (h Transport) Introspect(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	clientID, clientSecret := clientCredentials(c)
+
+	i, err := h.srv.Introspect(ctx, clientID, clientSecret, c.FormValue("token"))
+	if err != nil {
+		return h.tokenError(c, err)
+	}
+	if !i.Active {
+		return c.JSON(http.StatusOK, introspectionResponse{})
+	}
+
+	return c.JSON(http.StatusOK, introspectionResponse{
+		Active:    true,
+		Scope:     strings.Join(i.Scopes, " "),
+		ClientID:  i.ClientID,
+		TokenType: "Bearer",
+		Subject:   i.Subject,
+		ExpiresAt: i.ExpiresAt,
+		IssuedAt:  i.IssuedAt,
+	})
+}
+
+// clientCredentials returns the credentials of a client sent with basic
+// authentication or in the form body (RFC 6749 2.3.1)
+func clientCredentials(c echo.Context) (string, string) {
+	clientID, clientSecret, ok := c.Request().BasicAuth()
+	if !ok {
+		return c.FormValue("client_id"), c.FormValue("client_secret")
+	}
+
+	// credentials are form encoded before basic encoding
+	clientID, _ = url.QueryUnescape(clientID)
+	clientSecret, _ = url.QueryUnescape(clientSecret)
+
+	return clientID, clientSecret
+}
+
+func (h Transport) tokenError(c echo.Context, err error) error {
+	h.logger.Err(err).Msg("")
+
+	var oerr *Error
+	if !errors.As(err, &oerr) {
+		oerr = errServer
+	}
+
+	code := http.StatusBadRequest
+	switch oerr.Code {
+	case CodeInvalidClient:
+		code = http.StatusUnauthorized
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="oauth"`)
+	case CodeServerError:
+		code = http.StatusInternalServerError
+	}
+
+	return c.JSON(code, errorResponse{
+		Error:            oerr.Code,
+		ErrorDescription: oerr.Description,
+	})
+}
+
+// GetOauthConsent describes an authorization request to the consent page
+func (h Transport) GetOauthConsent(c echo.Context, params openapi.GetOauthConsentParams) error {
+	ctx := c.Request().Context()
+
+	req := &AuthorizationRequest{
+		ResponseType:        params.ResponseType,
+		ClientID:            params.ClientId,
+		RedirectURI:         params.RedirectUri,
+		CodeChallenge:       params.CodeChallenge,
+		CodeChallengeMethod: params.CodeChallengeMethod,
+	}
+	if params.Scope != nil {
+		req.Scope = *params.Scope
+	}
+
+	client, scopes, granted, err := h.srv.Consented(ctx, h.currentClaims(c).UserID, req)
+	if err != nil {
+		return h.consentError(err)
+	}
+
+	return c.JSON(http.StatusOK, openapi.OauthConsent{
+		ClientId:   client.ClientID,
+		ClientName: client.Name,
+		Scopes:     scopes,
+		Granted:    granted,
+	})
+}
+
+// GrantOauthConsent answers an authorization request for the current user and
+// returns where to send the user back to the client
+func (h Transport) GrantOauthConsent(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	body := &openapi.OauthConsentRequest{}
+	err := c.Bind(body)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	req := &AuthorizationRequest{
+		ResponseType:        body.ResponseType,
+		ClientID:            body.ClientId,
+		RedirectURI:         body.RedirectUri,
+		CodeChallenge:       body.CodeChallenge,
+		CodeChallengeMethod: body.CodeChallengeMethod,
+	}
+	if body.Scope != nil {
+		req.Scope = *body.Scope
+	}
+	if body.State != nil {
+		req.State = *body.State
+	}
+	if body.Nonce != nil {
+		req.Nonce = *body.Nonce
+	}
+
+	u, err := h.users.FindByID(ctx, h.currentClaims(c).UserID)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, user.ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	to, err := h.srv.Authorize(ctx, u, req, body.Approve)
+	if err != nil {
+		var oerr *Error
+		if errors.As(err, &oerr) && oerr.Redirectable() {
+			to = ErrorRedirect(req, oerr)
+		} else {
+			return h.consentError(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, openapi.OauthRedirect{
+		RedirectTo: to,
+	})
+}
+
+func (h Transport) consentError(err error) error {
+	h.logger.Err(err).Msg("")
+
+	var oerr *Error
+	if errors.As(err, &oerr) && oerr.Code != CodeServerError {
+		return echo.NewHTTPError(http.StatusBadRequest, oerr.Error())
+	}
+
+	return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+}
+
+// ListOauthConsents lists the clients the current user granted access to
+func (h Transport) ListOauthConsents(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	consents, err := h.srv.ListConsents(ctx, h.currentClaims(c).UserID)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	res := openapi.OauthConsentList{
+		Consents: make([]openapi.OauthGrant, 0, len(consents)),
+	}
+	for _, consent := range consents {
+		res.Consents = append(res.Consents, openapi.OauthGrant{
+			ClientId:  consent.ClientID,
+			Scopes:    consent.Scopes,
+			GrantedAt: consent.UpdatedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// RevokeOauthConsent revokes the consent of the current user to a client
+func (h Transport) RevokeOauthConsent(c echo.Context, clientID string) error {
+	ctx := c.Request().Context()
+
+	err := h.srv.RevokeConsent(ctx, h.currentClaims(c).UserID, clientID)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrConsentNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "consent revoked",
+	})
+}
+
+// ListOauthClients lists the registered clients
+func (h Transport) ListOauthClients(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clients, err := h.srv.ListClients(ctx)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	res := openapi.OauthClientList{
+		Clients: make([]openapi.OauthClient, 0, len(clients)),
+	}
+	for i := range clients {
+		res.Clients = append(res.Clients, oauthClient(&clients[i]))
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// CreateOauthClient registers a client
+func (h Transport) CreateOauthClient(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.OauthClientCreateRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	client, clientSecret, err := h.srv.CreateClient(ctx, req.Name, req.RedirectUris, req.Scopes, req.Confidential)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrInvalidClientReq) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	res := openapi.OauthClientCreateResponse{
+		Client: oauthClient(client),
+	}
+	if clientSecret != "" {
+		res.ClientSecret = &clientSecret
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteOauthClient deletes a client
+func (h Transport) DeleteOauthClient(c echo.Context, clientID string) error {
+	ctx := c.Request().Context()
+
+	err := h.srv.DeleteClient(ctx, clientID)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrClientNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "oauth client deleted",
+	})
+}
+
+func oauthClient(c *Client) openapi.OauthClient {
+	return openapi.OauthClient{
+		ClientId:     c.ClientID,
+		Name:         c.Name,
+		Confidential: c.Confidential(),
+		RedirectUris: c.RedirectURIs,
+		Scopes:       c.Scopes,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+func authorizationRequest(q url.Values) *AuthorizationRequest {
+	return &AuthorizationRequest{
+		ResponseType:        q.Get("response_type"),
+		ClientID:            q.Get("client_id"),
+		RedirectURI:         q.Get("redirect_uri"),
+		Scope:               q.Get("scope"),
+		State:               q.Get("state"),
+		CodeChallenge:       q.Get("code_challenge"),
+		CodeChallengeMethod: q.Get("code_challenge_method"),
+		Nonce:               q.Get("nonce"),
+	}
+}
+
+// GetUserinfo returns the claims about the user an access token with the
+// openid scope was issued for
+func (h Transport) GetUserinfo(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	claims := h.currentClaims(c)
+
+	info, err := h.srv.UserInfo(ctx, claims.UserID, strings.Fields(claims.Scope))
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		switch {
+		case errors.Is(err, ErrInsufficientScope):
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="insufficient_scope", scope="openid"`)
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case errors.Is(err, user.ErrUserNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.UserInfo{
+		Sub:           info.Subject,
+		Email:         optional(info.Email),
+		EmailVerified: info.EmailVerified,
+		Name:          optional(info.Name),
+		GivenName:     optional(info.GivenName),
+		FamilyName:    optional(info.FamilyName),
+		Picture:       optional(info.Picture),
+	})
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -8,23 +8,354 @@ import (
 	"compress/gzip"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
 )
 
+// AccountDeleteRequest defines model for AccountDeleteRequest.
+type AccountDeleteRequest struct {
+	Password string `json:"password"`
+}
+
+// AdminActionRequest defines model for AdminActionRequest.
+type AdminActionRequest struct {
+
+	// Why the action is taken, kept in the audit log
+	Reason string `json:"reason"`
+}
+
+// AdminUser defines model for AdminUser.
+type AdminUser struct {
+	Active        bool       `json:"active"`
+	CreatedAt     time.Time  `json:"created_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+	Email         string     `json:"email"`
+	EmailVerified bool       `json:"email_verified"`
+	FirstName     string     `json:"first_name"`
+	Id            int        `json:"id"`
+	LastName      string     `json:"last_name"`
+	Mobile        string     `json:"mobile"`
+	Roles         []string   `json:"roles"`
+}
+
+// ApiKey defines model for ApiKey.
+type ApiKey struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Id         int        `json:"id"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Name       string     `json:"name"`
+
+	// Start of the key, to recognize it
+	Prefix string   `json:"prefix"`
+	Scopes []string `json:"scopes"`
+}
+
+// ApiKeyCreateRequest defines model for ApiKeyCreateRequest.
+type ApiKeyCreateRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
+
+	// Permissions of the key, a subset of the permissions of the user
+	Scopes []string `json:"scopes"`
+}
+
+// ApiKeyCreateResponse defines model for ApiKeyCreateResponse.
+type ApiKeyCreateResponse struct {
+	ApiKey ApiKey `json:"api_key"`
+
+	// Send it in the X-API-Key header, it is not shown again
+	Key string `json:"key"`
+}
+
+// ApiKeyList defines model for ApiKeyList.
+type ApiKeyList struct {
+	ApiKeys []ApiKey `json:"api_keys"`
+}
+
+// ApiKeyUpdateRequest defines model for ApiKeyUpdateRequest.
+type ApiKeyUpdateRequest struct {
+	Name   *string   `json:"name,omitempty"`
+	Scopes *[]string `json:"scopes,omitempty"`
+}
+
+// ContactChangeConfirmRequest defines model for ContactChangeConfirmRequest.
+type ContactChangeConfirmRequest struct {
+	Code string `json:"code"`
+}
+
+// EmailChangeRequest defines model for EmailChangeRequest.
+type EmailChangeRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// EmailVerifyRequest defines model for EmailVerifyRequest.
+type EmailVerifyRequest struct {
+	Token string `json:"token"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
+// Export defines model for Export.
+type Export struct {
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	// The archive can be downloaded until then
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Format    string     `json:"format"`
+	Id        string     `json:"id"`
+	Status    string     `json:"status"`
+}
+
+// ExportRequest defines model for ExportRequest.
+type ExportRequest struct {
+	Format string `json:"format"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+
+	// Rule the field broke: required, too_short, too_long, too_simple, personal_info or breached
+	Code    string `json:"code"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ImpersonationRequest defines model for ImpersonationRequest.
+type ImpersonationRequest struct {
+
+	// Why the user is impersonated, e.g. a support ticket
+	Reason string `json:"reason"`
+}
+
+// ImpersonationResponse defines model for ImpersonationResponse.
+type ImpersonationResponse struct {
+	ExpiresIn int    `json:"expires_in"`
+	Token     string `json:"token"`
+	UserId    int    `json:"user_id"`
+}
+
+// LogoutRequest defines model for LogoutRequest.
+type LogoutRequest struct {
+	RefreshToken *string `json:"refresh_token,omitempty"`
+}
+
+// MagicLinkRedeemRequest defines model for MagicLinkRedeemRequest.
+type MagicLinkRedeemRequest struct {
+	Token string `json:"token"`
+}
+
+// MagicLinkRequest defines model for MagicLinkRequest.
+type MagicLinkRequest struct {
+	Email string `json:"email"`
+}
+
+// MfaChangeRequest defines model for MfaChangeRequest.
+type MfaChangeRequest struct {
+	Code string `json:"code"`
+
+	// Current password of the user
+	Password string `json:"password"`
+}
+
+// MfaCodeRequest defines model for MfaCodeRequest.
+type MfaCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// MfaLoginRequest defines model for MfaLoginRequest.
+type MfaLoginRequest struct {
+	Code     string `json:"code"`
+	MfaToken string `json:"mfa_token"`
+}
+
+// MobileChangeRequest defines model for MobileChangeRequest.
+type MobileChangeRequest struct {
+	Mobile   string `json:"mobile"`
+	Password string `json:"password"`
+}
+
+// OauthClient defines model for OauthClient.
+type OauthClient struct {
+	ClientId     string    `json:"client_id"`
+	Confidential bool      `json:"confidential"`
+	CreatedAt    time.Time `json:"created_at"`
+	Name         string    `json:"name"`
+	RedirectUris []string  `json:"redirect_uris"`
+	Scopes       []string  `json:"scopes"`
+}
+
+// OauthClientCreateRequest defines model for OauthClientCreateRequest.
+type OauthClientCreateRequest struct {
+
+	// Confidential clients get a secret and may use the client credentials grant
+	Confidential bool     `json:"confidential"`
+	Name         string   `json:"name"`
+	RedirectUris []string `json:"redirect_uris"`
+
+	// Scopes the client may ask for, they map onto permissions
+	Scopes []string `json:"scopes"`
+}
+
+// OauthClientCreateResponse defines model for OauthClientCreateResponse.
+type OauthClientCreateResponse struct {
+	Client       OauthClient `json:"client"`
+	ClientSecret *string     `json:"client_secret,omitempty"`
+}
+
+// OauthClientList defines model for OauthClientList.
+type OauthClientList struct {
+	Clients []OauthClient `json:"clients"`
+}
+
+// OauthConsent defines model for OauthConsent.
+type OauthConsent struct {
+	ClientId   string `json:"client_id"`
+	ClientName string `json:"client_name"`
+
+	// The user already consented to every scope, the request can be approved without asking
+	Granted bool     `json:"granted"`
+	Scopes  []string `json:"scopes"`
+}
+
+// OauthConsentList defines model for OauthConsentList.
+type OauthConsentList struct {
+	Consents []OauthGrant `json:"consents"`
+}
+
+// OauthConsentRequest defines model for OauthConsentRequest.
+type OauthConsentRequest struct {
+	Approve             bool   `json:"approve"`
+	ClientId            string `json:"client_id"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+
+	// Nonce of an OpenID Connect request, echoed in the ID token
+	Nonce        *string `json:"nonce,omitempty"`
+	RedirectUri  string  `json:"redirect_uri"`
+	ResponseType string  `json:"response_type"`
+	Scope        *string `json:"scope,omitempty"`
+	State        *string `json:"state,omitempty"`
+}
+
+// OauthGrant defines model for OauthGrant.
+type OauthGrant struct {
+	ClientId  string    `json:"client_id"`
+	GrantedAt time.Time `json:"granted_at"`
+	Scopes    []string  `json:"scopes"`
+}
+
+// OauthRedirect defines model for OauthRedirect.
+type OauthRedirect struct {
+
+	// Where to send the user back to the client
+	RedirectTo string `json:"redirect_to"`
+}
+
+// PasswordChangeRequest defines model for PasswordChangeRequest.
+type PasswordChangeRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// PasswordForgotRequest defines model for PasswordForgotRequest.
+type PasswordForgotRequest struct {
+	Email string `json:"email"`
+}
+
+// PasswordResetRequest defines model for PasswordResetRequest.
+type PasswordResetRequest struct {
+	NewPassword string `json:"new_password"`
+	Token       string `json:"token"`
+}
+
+// RecoveryCodes defines model for RecoveryCodes.
+type RecoveryCodes struct {
+
+	// One time codes, they are shown only once
+	Codes []string `json:"codes"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Whether the request was made from this session
+	Current    bool      `json:"current"`
+	Id         string    `json:"id"`
+	Ip         string    `json:"ip"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	UserAgent  string    `json:"user_agent"`
+}
+
+// SessionList defines model for SessionList.
+type SessionList struct {
+	Sessions []Session `json:"sessions"`
+}
+
 // Status defines model for Status.
 type Status struct {
 	Message string `json:"message"`
 }
 
+// Thumbnail defines model for Thumbnail.
+type Thumbnail struct {
+
+	// Width and height in pixels
+	Size int    `json:"size"`
+	Url  string `json:"url"`
+}
+
+// TokenRefreshRequest defines model for TokenRefreshRequest.
+type TokenRefreshRequest struct {
+	RefreshToken *string `json:"refresh_token,omitempty"`
+}
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Token        string `json:"token"`
+}
+
+// TotpEnrollment defines model for TotpEnrollment.
+type TotpEnrollment struct {
+	Secret string `json:"secret"`
+
+	// otpauth URI to show as a QR code
+	Uri string `json:"uri"`
+}
+
+// UserInfo defines model for UserInfo.
+type UserInfo struct {
+	Email         *string `json:"email,omitempty"`
+	EmailVerified *bool   `json:"email_verified,omitempty"`
+	FamilyName    *string `json:"family_name,omitempty"`
+	GivenName     *string `json:"given_name,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	Picture       *string `json:"picture,omitempty"`
+	Sub           string  `json:"sub"`
+}
+
+// UserList defines model for UserList.
+type UserList struct {
+
+	// Cursor of the next page, missing on the last page
+	NextCursor *string     `json:"next_cursor,omitempty"`
+	Users      []AdminUser `json:"users"`
+}
+
 // UserLoginRequest defines model for UserLoginRequest.
 type UserLoginRequest struct {
 	Email    string `json:"email"`
@@ -33,9 +364,28 @@ type UserLoginRequest struct {
 
 // UserLoginResponse defines model for UserLoginResponse.
 type UserLoginResponse struct {
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Token     string `json:"token"`
+	ExpiresIn    *int    `json:"expires_in,omitempty"`
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	MfaRequired  bool    `json:"mfa_required"`
+	MfaToken     *string `json:"mfa_token,omitempty"`
+	RefreshToken *string `json:"refresh_token,omitempty"`
+	Token        *string `json:"token,omitempty"`
+}
+
+// UserProfile defines model for UserProfile.
+type UserProfile struct {
+	Address       string `json:"address"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	FirstName     string `json:"first_name"`
+
+	// Largest thumbnail of the avatar, empty without one
+	ImageUrl   string      `json:"image_url"`
+	LastName   string      `json:"last_name"`
+	Mobile     string      `json:"mobile"`
+	Roles      []string    `json:"roles"`
+	Thumbnails []Thumbnail `json:"thumbnails"`
 }
 
 // UserRegistrationRequest defines model for UserRegistrationRequest.
@@ -48,26 +398,390 @@ type UserRegistrationRequest struct {
 	Password  string `json:"password"`
 }
 
+// UserUpdateRequest defines model for UserUpdateRequest.
+type UserUpdateRequest struct {
+	Address   *string `json:"address,omitempty"`
+	FirstName *string `json:"first_name,omitempty"`
+	LastName  *string `json:"last_name,omitempty"`
+}
+
+// ValidationError defines model for ValidationError.
+type ValidationError struct {
+	Fields  []FieldError `json:"fields"`
+	Message string       `json:"message"`
+}
+
+// CreateOauthClientJSONBody defines parameters for CreateOauthClient.
+type CreateOauthClientJSONBody OauthClientCreateRequest
+
+// ListUsersParams defines parameters for ListUsers.
+type ListUsersParams struct {
+	Active        *bool      `json:"active,omitempty"`
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+
+	// Prefix of the email
+	Email *string `json:"email,omitempty"`
+
+	// Prefix of the first or last name
+	Name           *string `json:"name,omitempty"`
+	IncludeDeleted *bool   `json:"include_deleted,omitempty"`
+	Sort           *string `json:"sort,omitempty"`
+	Order          *string `json:"order,omitempty"`
+	Limit          *int    `json:"limit,omitempty"`
+	Cursor         *string `json:"cursor,omitempty"`
+}
+
+// EraseUserJSONBody defines parameters for EraseUser.
+type EraseUserJSONBody AdminActionRequest
+
+// ImpersonateUserJSONBody defines parameters for ImpersonateUser.
+type ImpersonateUserJSONBody ImpersonationRequest
+
+// ReactivateUserJSONBody defines parameters for ReactivateUser.
+type ReactivateUserJSONBody AdminActionRequest
+
+// RestoreUserJSONBody defines parameters for RestoreUser.
+type RestoreUserJSONBody AdminActionRequest
+
+// SuspendUserJSONBody defines parameters for SuspendUser.
+type SuspendUserJSONBody AdminActionRequest
+
+// GetOauthConsentParams defines parameters for GetOauthConsent.
+type GetOauthConsentParams struct {
+	ResponseType string `json:"response_type"`
+	ClientId     string `json:"client_id"`
+	RedirectUri  string `json:"redirect_uri"`
+
+	// Space delimited, all scopes of the client when omitted
+	Scope               *string `json:"scope,omitempty"`
+	CodeChallenge       string  `json:"code_challenge"`
+	CodeChallengeMethod string  `json:"code_challenge_method"`
+}
+
+// GrantOauthConsentJSONBody defines parameters for GrantOauthConsent.
+type GrantOauthConsentJSONBody OauthConsentRequest
+
+// CreateApiKeyJSONBody defines parameters for CreateApiKey.
+type CreateApiKeyJSONBody ApiKeyCreateRequest
+
+// UpdateApiKeyJSONBody defines parameters for UpdateApiKey.
+type UpdateApiKeyJSONBody ApiKeyUpdateRequest
+
+// CreateExportJSONBody defines parameters for CreateExport.
+type CreateExportJSONBody ExportRequest
+
 // LoginUserJSONBody defines parameters for LoginUser.
 type LoginUserJSONBody UserLoginRequest
 
+// SendMagicLinkJSONBody defines parameters for SendMagicLink.
+type SendMagicLinkJSONBody MagicLinkRequest
+
+// RedeemMagicLinkJSONBody defines parameters for RedeemMagicLink.
+type RedeemMagicLinkJSONBody MagicLinkRedeemRequest
+
+// LoginUserMfaJSONBody defines parameters for LoginUserMfa.
+type LoginUserMfaJSONBody MfaLoginRequest
+
+// LogoutUserJSONBody defines parameters for LogoutUser.
+type LogoutUserJSONBody LogoutRequest
+
+// DeleteMeJSONBody defines parameters for DeleteMe.
+type DeleteMeJSONBody AccountDeleteRequest
+
+// UpdateMeJSONBody defines parameters for UpdateMe.
+type UpdateMeJSONBody UserUpdateRequest
+
+// ChangeEmailJSONBody defines parameters for ChangeEmail.
+type ChangeEmailJSONBody EmailChangeRequest
+
+// ConfirmEmailChangeJSONBody defines parameters for ConfirmEmailChange.
+type ConfirmEmailChangeJSONBody ContactChangeConfirmRequest
+
+// ChangeMobileJSONBody defines parameters for ChangeMobile.
+type ChangeMobileJSONBody MobileChangeRequest
+
+// ConfirmMobileChangeJSONBody defines parameters for ConfirmMobileChange.
+type ConfirmMobileChangeJSONBody ContactChangeConfirmRequest
+
+// RegenerateRecoveryCodesJSONBody defines parameters for RegenerateRecoveryCodes.
+type RegenerateRecoveryCodesJSONBody MfaChangeRequest
+
+// ConfirmTotpJSONBody defines parameters for ConfirmTotp.
+type ConfirmTotpJSONBody MfaCodeRequest
+
+// DisableTotpJSONBody defines parameters for DisableTotp.
+type DisableTotpJSONBody MfaChangeRequest
+
+// ChangePasswordJSONBody defines parameters for ChangePassword.
+type ChangePasswordJSONBody PasswordChangeRequest
+
+// ForgotPasswordJSONBody defines parameters for ForgotPassword.
+type ForgotPasswordJSONBody PasswordForgotRequest
+
+// ResetPasswordJSONBody defines parameters for ResetPassword.
+type ResetPasswordJSONBody PasswordResetRequest
+
 // RegisterUserJSONBody defines parameters for RegisterUser.
 type RegisterUserJSONBody UserRegistrationRequest
 
+// RefreshTokenJSONBody defines parameters for RefreshToken.
+type RefreshTokenJSONBody TokenRefreshRequest
+
+// VerifyEmailJSONBody defines parameters for VerifyEmail.
+type VerifyEmailJSONBody EmailVerifyRequest
+
+// CreateOauthClientRequestBody defines body for CreateOauthClient for application/json ContentType.
+type CreateOauthClientJSONRequestBody CreateOauthClientJSONBody
+
+// EraseUserRequestBody defines body for EraseUser for application/json ContentType.
+type EraseUserJSONRequestBody EraseUserJSONBody
+
+// ImpersonateUserRequestBody defines body for ImpersonateUser for application/json ContentType.
+type ImpersonateUserJSONRequestBody ImpersonateUserJSONBody
+
+// ReactivateUserRequestBody defines body for ReactivateUser for application/json ContentType.
+type ReactivateUserJSONRequestBody ReactivateUserJSONBody
+
+// RestoreUserRequestBody defines body for RestoreUser for application/json ContentType.
+type RestoreUserJSONRequestBody RestoreUserJSONBody
+
+// SuspendUserRequestBody defines body for SuspendUser for application/json ContentType.
+type SuspendUserJSONRequestBody SuspendUserJSONBody
+
+// GrantOauthConsentRequestBody defines body for GrantOauthConsent for application/json ContentType.
+type GrantOauthConsentJSONRequestBody GrantOauthConsentJSONBody
+
+// CreateApiKeyRequestBody defines body for CreateApiKey for application/json ContentType.
+type CreateApiKeyJSONRequestBody CreateApiKeyJSONBody
+
+// UpdateApiKeyRequestBody defines body for UpdateApiKey for application/json ContentType.
+type UpdateApiKeyJSONRequestBody UpdateApiKeyJSONBody
+
+// CreateExportRequestBody defines body for CreateExport for application/json ContentType.
+type CreateExportJSONRequestBody CreateExportJSONBody
+
 // LoginUserRequestBody defines body for LoginUser for application/json ContentType.
 type LoginUserJSONRequestBody LoginUserJSONBody
 
+// SendMagicLinkRequestBody defines body for SendMagicLink for application/json ContentType.
+type SendMagicLinkJSONRequestBody SendMagicLinkJSONBody
+
+// RedeemMagicLinkRequestBody defines body for RedeemMagicLink for application/json ContentType.
+type RedeemMagicLinkJSONRequestBody RedeemMagicLinkJSONBody
+
+// LoginUserMfaRequestBody defines body for LoginUserMfa for application/json ContentType.
+type LoginUserMfaJSONRequestBody LoginUserMfaJSONBody
+
+// LogoutUserRequestBody defines body for LogoutUser for application/json ContentType.
+type LogoutUserJSONRequestBody LogoutUserJSONBody
+
+// DeleteMeRequestBody defines body for DeleteMe for application/json ContentType.
+type DeleteMeJSONRequestBody DeleteMeJSONBody
+
+// UpdateMeRequestBody defines body for UpdateMe for application/json ContentType.
+type UpdateMeJSONRequestBody UpdateMeJSONBody
+
+// ChangeEmailRequestBody defines body for ChangeEmail for application/json ContentType.
+type ChangeEmailJSONRequestBody ChangeEmailJSONBody
+
+// ConfirmEmailChangeRequestBody defines body for ConfirmEmailChange for application/json ContentType.
+type ConfirmEmailChangeJSONRequestBody ConfirmEmailChangeJSONBody
+
+// ChangeMobileRequestBody defines body for ChangeMobile for application/json ContentType.
+type ChangeMobileJSONRequestBody ChangeMobileJSONBody
+
+// ConfirmMobileChangeRequestBody defines body for ConfirmMobileChange for application/json ContentType.
+type ConfirmMobileChangeJSONRequestBody ConfirmMobileChangeJSONBody
+
+// RegenerateRecoveryCodesRequestBody defines body for RegenerateRecoveryCodes for application/json ContentType.
+type RegenerateRecoveryCodesJSONRequestBody RegenerateRecoveryCodesJSONBody
+
+// ConfirmTotpRequestBody defines body for ConfirmTotp for application/json ContentType.
+type ConfirmTotpJSONRequestBody ConfirmTotpJSONBody
+
+// DisableTotpRequestBody defines body for DisableTotp for application/json ContentType.
+type DisableTotpJSONRequestBody DisableTotpJSONBody
+
+// ChangePasswordRequestBody defines body for ChangePassword for application/json ContentType.
+type ChangePasswordJSONRequestBody ChangePasswordJSONBody
+
+// ForgotPasswordRequestBody defines body for ForgotPassword for application/json ContentType.
+type ForgotPasswordJSONRequestBody ForgotPasswordJSONBody
+
+// ResetPasswordRequestBody defines body for ResetPassword for application/json ContentType.
+type ResetPasswordJSONRequestBody ResetPasswordJSONBody
+
 // RegisterUserRequestBody defines body for RegisterUser for application/json ContentType.
 type RegisterUserJSONRequestBody RegisterUserJSONBody
 
+// RefreshTokenRequestBody defines body for RefreshToken for application/json ContentType.
+type RefreshTokenJSONRequestBody RefreshTokenJSONBody
+
+// VerifyEmailRequestBody defines body for VerifyEmail for application/json ContentType.
+type VerifyEmailJSONRequestBody VerifyEmailJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (GET /admin/oauth/clients)
+	ListOauthClients(ctx echo.Context) error
+
+	// (POST /admin/oauth/clients)
+	CreateOauthClient(ctx echo.Context) error
+
+	// (DELETE /admin/oauth/clients/{client_id})
+	DeleteOauthClient(ctx echo.Context, clientId string) error
+
+	// (GET /admin/users)
+	ListUsers(ctx echo.Context, params ListUsersParams) error
+
+	// (POST /admin/users/{id}/erase)
+	EraseUser(ctx echo.Context, id int) error
+
+	// (POST /admin/users/{id}/impersonate)
+	ImpersonateUser(ctx echo.Context, id int) error
+
+	// (POST /admin/users/{id}/reactivate)
+	ReactivateUser(ctx echo.Context, id int) error
+
+	// (POST /admin/users/{id}/restore)
+	RestoreUser(ctx echo.Context, id int) error
+
+	// (DELETE /admin/users/{id}/roles/{role})
+	RemoveUserRole(ctx echo.Context, id int, role string) error
+
+	// (PUT /admin/users/{id}/roles/{role})
+	AssignUserRole(ctx echo.Context, id int, role string) error
+
+	// (POST /admin/users/{id}/suspend)
+	SuspendUser(ctx echo.Context, id int) error
+
+	// (GET /oauth/consent)
+	GetOauthConsent(ctx echo.Context, params GetOauthConsentParams) error
+
+	// (POST /oauth/consent)
+	GrantOauthConsent(ctx echo.Context) error
+
+	// (GET /user/api-keys)
+	ListApiKeys(ctx echo.Context) error
+
+	// (POST /user/api-keys)
+	CreateApiKey(ctx echo.Context) error
+
+	// (DELETE /user/api-keys/{id})
+	DeleteApiKey(ctx echo.Context, id int) error
+
+	// (GET /user/api-keys/{id})
+	GetApiKey(ctx echo.Context, id int) error
+
+	// (PATCH /user/api-keys/{id})
+	UpdateApiKey(ctx echo.Context, id int) error
+
+	// (POST /user/exports)
+	CreateExport(ctx echo.Context) error
+
+	// (GET /user/exports/{id})
+	GetExport(ctx echo.Context, id string) error
+
+	// (GET /user/exports/{id}/archive)
+	DownloadExport(ctx echo.Context, id string) error
+
+	// (DELETE /user/impersonation)
+	StopImpersonation(ctx echo.Context) error
+
 	// (POST /user/login)
 	LoginUser(ctx echo.Context) error
 
+	// (POST /user/login/magic-link)
+	SendMagicLink(ctx echo.Context) error
+
+	// (POST /user/login/magic-link/redeem)
+	RedeemMagicLink(ctx echo.Context) error
+
+	// (POST /user/login/mfa)
+	LoginUserMfa(ctx echo.Context) error
+
+	// (POST /user/logout)
+	LogoutUser(ctx echo.Context) error
+
+	// (POST /user/logout/all)
+	LogoutAllSessions(ctx echo.Context) error
+
+	// (DELETE /user/me)
+	DeleteMe(ctx echo.Context) error
+
+	// (GET /user/me)
+	GetMe(ctx echo.Context) error
+
+	// (PATCH /user/me)
+	UpdateMe(ctx echo.Context) error
+
+	// (DELETE /user/me/avatar)
+	DeleteAvatar(ctx echo.Context) error
+
+	// (PUT /user/me/avatar)
+	UploadAvatar(ctx echo.Context) error
+
+	// (POST /user/me/email)
+	ChangeEmail(ctx echo.Context) error
+
+	// (POST /user/me/email/confirm)
+	ConfirmEmailChange(ctx echo.Context) error
+
+	// (POST /user/me/mobile)
+	ChangeMobile(ctx echo.Context) error
+
+	// (POST /user/me/mobile/confirm)
+	ConfirmMobileChange(ctx echo.Context) error
+
+	// (POST /user/mfa/recovery-codes)
+	RegenerateRecoveryCodes(ctx echo.Context) error
+
+	// (POST /user/mfa/totp)
+	EnrollTotp(ctx echo.Context) error
+
+	// (POST /user/mfa/totp/confirm)
+	ConfirmTotp(ctx echo.Context) error
+
+	// (POST /user/mfa/totp/disable)
+	DisableTotp(ctx echo.Context) error
+
+	// (GET /user/oauth/consents)
+	ListOauthConsents(ctx echo.Context) error
+
+	// (DELETE /user/oauth/consents/{client_id})
+	RevokeOauthConsent(ctx echo.Context, clientId string) error
+
+	// (POST /user/password)
+	ChangePassword(ctx echo.Context) error
+
+	// (POST /user/password/forgot)
+	ForgotPassword(ctx echo.Context) error
+
+	// (POST /user/password/reset)
+	ResetPassword(ctx echo.Context) error
+
 	// (POST /user/register)
 	RegisterUser(ctx echo.Context) error
+
+	// (GET /user/sessions)
+	ListSessions(ctx echo.Context) error
+
+	// (DELETE /user/sessions/{id})
+	DeleteSession(ctx echo.Context, id string) error
+
+	// (POST /user/token/refresh)
+	RefreshToken(ctx echo.Context) error
+
+	// (POST /user/verify-email)
+	VerifyEmail(ctx echo.Context) error
+
+	// (POST /user/verify-email/resend)
+	ResendEmailVerification(ctx echo.Context) error
+
+	// (GET /userinfo)
+	GetUserinfo(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -75,21 +789,823 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
-// LoginUser converts echo context to params.
-func (w *ServerInterfaceWrapper) LoginUser(ctx echo.Context) error {
+// ListOauthClients converts echo context to params.
+func (w *ServerInterfaceWrapper) ListOauthClients(ctx echo.Context) error {
 	var err error
 
+	ctx.Set("bearerAuth.Scopes", []string{"admin"})
+
+	ctx.Set("apiKeyAuth.Scopes", []string{"admin"})
+
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.LoginUser(ctx)
+	err = w.Handler.ListOauthClients(ctx)
 	return err
 }
 
-// RegisterUser converts echo context to params.
-func (w *ServerInterfaceWrapper) RegisterUser(ctx echo.Context) error {
+// CreateOauthClient converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateOauthClient(ctx echo.Context) error {
 	var err error
 
+	ctx.Set("bearerAuth.Scopes", []string{"admin"})
+
+	ctx.Set("apiKeyAuth.Scopes", []string{"admin"})
+
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.RegisterUser(ctx)
+	err = w.Handler.CreateOauthClient(ctx)
+	return err
+}
+
+// DeleteOauthClient converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteOauthClient(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "client_id" -------------
+	var clientId string
+
+	err = runtime.BindStyledParameter("simple", false, "client_id", ctx.Param("client_id"), &clientId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter client_id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{"admin"})
+
+	ctx.Set("apiKeyAuth.Scopes", []string{"admin"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteOauthClient(ctx, clientId)
+	return err
+}
+
+// ListUsers converts echo context to params.
+func (w *ServerInterfaceWrapper) ListUsers(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{"admin"})
+
+	ctx.Set("apiKeyAuth.Scopes", []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUsersParams
+	// ------------- Optional query parameter "active" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "active", ctx.QueryParams(), &params.Active)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter active: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_after", ctx.QueryParams(), &params.CreatedAfter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_after: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_before", ctx.QueryParams(), &params.CreatedBefore)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_before: %s", err))
+	}
+
+	// ------------- Optional query parameter "email" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "email", ctx.QueryParams(), &params.Email)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter email: %s", err))
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", ctx.QueryParams(), &params.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Optional query parameter "include_deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deleted", ctx.QueryParams(), &params.IncludeDeleted)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include_deleted: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", ctx.QueryParams(), &params.Order)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListUsers(ctx, params)
+	return err
+}
+
+// EraseUser converts echo context to params.
+func (w *ServerInterfaceWrapper) EraseUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{"admin"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.EraseUser(ctx, id)
+	return err
+}
+
+// ImpersonateUser converts echo context to params.
+func (w *ServerInterfaceWrapper) ImpersonateUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{"admin"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ImpersonateUser(ctx, id)
+	return err
+}
+
+// ReactivateUser converts echo context to params.
+func (w *ServerInterfaceWrapper) ReactivateUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{"admin"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ReactivateUser(ctx, id)
+	return err
+}
+
+// RestoreUser converts echo context to params.
+func (w *ServerInterfaceWrapper) RestoreUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{"admin"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RestoreUser(ctx, id)
+	return err
+}
+
+// RemoveUserRole converts echo context to params.
+func (w *ServerInterfaceWrapper) RemoveUserRole(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "role" -------------
+	var role string
+
+	err = runtime.BindStyledParameter("simple", false, "role", ctx.Param("role"), &role)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter role: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{"admin"})
+
+	ctx.Set("apiKeyAuth.Scopes", []string{"admin"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RemoveUserRole(ctx, id, role)
+	return err
+}
+
+// AssignUserRole converts echo context to params.
+func (w *ServerInterfaceWrapper) AssignUserRole(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "role" -------------
+	var role string
+
+	err = runtime.BindStyledParameter("simple", false, "role", ctx.Param("role"), &role)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter role: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{"admin"})
+
+	ctx.Set("apiKeyAuth.Scopes", []string{"admin"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AssignUserRole(ctx, id, role)
+	return err
+}
+
+// SuspendUser converts echo context to params.
+func (w *ServerInterfaceWrapper) SuspendUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{"admin"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SuspendUser(ctx, id)
+	return err
+}
+
+// GetOauthConsent converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOauthConsent(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOauthConsentParams
+	// ------------- Required query parameter "response_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "response_type", ctx.QueryParams(), &params.ResponseType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter response_type: %s", err))
+	}
+
+	// ------------- Required query parameter "client_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "client_id", ctx.QueryParams(), &params.ClientId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter client_id: %s", err))
+	}
+
+	// ------------- Required query parameter "redirect_uri" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "redirect_uri", ctx.QueryParams(), &params.RedirectUri)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter redirect_uri: %s", err))
+	}
+
+	// ------------- Optional query parameter "scope" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "scope", ctx.QueryParams(), &params.Scope)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter scope: %s", err))
+	}
+
+	// ------------- Required query parameter "code_challenge" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "code_challenge", ctx.QueryParams(), &params.CodeChallenge)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter code_challenge: %s", err))
+	}
+
+	// ------------- Required query parameter "code_challenge_method" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "code_challenge_method", ctx.QueryParams(), &params.CodeChallengeMethod)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter code_challenge_method: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetOauthConsent(ctx, params)
+	return err
+}
+
+// GrantOauthConsent converts echo context to params.
+func (w *ServerInterfaceWrapper) GrantOauthConsent(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GrantOauthConsent(ctx)
+	return err
+}
+
+// ListApiKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) ListApiKeys(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListApiKeys(ctx)
+	return err
+}
+
+// CreateApiKey converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateApiKey(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CreateApiKey(ctx)
+	return err
+}
+
+// DeleteApiKey converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteApiKey(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteApiKey(ctx, id)
+	return err
+}
+
+// GetApiKey converts echo context to params.
+func (w *ServerInterfaceWrapper) GetApiKey(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetApiKey(ctx, id)
+	return err
+}
+
+// UpdateApiKey converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateApiKey(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.UpdateApiKey(ctx, id)
+	return err
+}
+
+// CreateExport converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateExport(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CreateExport(ctx)
+	return err
+}
+
+// GetExport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetExport(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetExport(ctx, id)
+	return err
+}
+
+// DownloadExport converts echo context to params.
+func (w *ServerInterfaceWrapper) DownloadExport(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DownloadExport(ctx, id)
+	return err
+}
+
+// StopImpersonation converts echo context to params.
+func (w *ServerInterfaceWrapper) StopImpersonation(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.StopImpersonation(ctx)
+	return err
+}
+
+// LoginUser converts echo context to params.
+func (w *ServerInterfaceWrapper) LoginUser(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LoginUser(ctx)
+	return err
+}
+
+// SendMagicLink converts echo context to params.
+func (w *ServerInterfaceWrapper) SendMagicLink(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SendMagicLink(ctx)
+	return err
+}
+
+// RedeemMagicLink converts echo context to params.
+func (w *ServerInterfaceWrapper) RedeemMagicLink(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RedeemMagicLink(ctx)
+	return err
+}
+
+// LoginUserMfa converts echo context to params.
+func (w *ServerInterfaceWrapper) LoginUserMfa(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LoginUserMfa(ctx)
+	return err
+}
+
+// LogoutUser converts echo context to params.
+func (w *ServerInterfaceWrapper) LogoutUser(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LogoutUser(ctx)
+	return err
+}
+
+// LogoutAllSessions converts echo context to params.
+func (w *ServerInterfaceWrapper) LogoutAllSessions(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LogoutAllSessions(ctx)
+	return err
+}
+
+// DeleteMe converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteMe(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	ctx.Set("apiKeyAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteMe(ctx)
+	return err
+}
+
+// GetMe converts echo context to params.
+func (w *ServerInterfaceWrapper) GetMe(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	ctx.Set("apiKeyAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetMe(ctx)
+	return err
+}
+
+// UpdateMe converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateMe(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.UpdateMe(ctx)
+	return err
+}
+
+// DeleteAvatar converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteAvatar(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteAvatar(ctx)
+	return err
+}
+
+// UploadAvatar converts echo context to params.
+func (w *ServerInterfaceWrapper) UploadAvatar(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.UploadAvatar(ctx)
+	return err
+}
+
+// ChangeEmail converts echo context to params.
+func (w *ServerInterfaceWrapper) ChangeEmail(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ChangeEmail(ctx)
+	return err
+}
+
+// ConfirmEmailChange converts echo context to params.
+func (w *ServerInterfaceWrapper) ConfirmEmailChange(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ConfirmEmailChange(ctx)
+	return err
+}
+
+// ChangeMobile converts echo context to params.
+func (w *ServerInterfaceWrapper) ChangeMobile(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ChangeMobile(ctx)
+	return err
+}
+
+// ConfirmMobileChange converts echo context to params.
+func (w *ServerInterfaceWrapper) ConfirmMobileChange(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ConfirmMobileChange(ctx)
+	return err
+}
+
+// RegenerateRecoveryCodes converts echo context to params.
+func (w *ServerInterfaceWrapper) RegenerateRecoveryCodes(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RegenerateRecoveryCodes(ctx)
+	return err
+}
+
+// EnrollTotp converts echo context to params.
+func (w *ServerInterfaceWrapper) EnrollTotp(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.EnrollTotp(ctx)
+	return err
+}
+
+// ConfirmTotp converts echo context to params.
+func (w *ServerInterfaceWrapper) ConfirmTotp(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ConfirmTotp(ctx)
+	return err
+}
+
+// DisableTotp converts echo context to params.
+func (w *ServerInterfaceWrapper) DisableTotp(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DisableTotp(ctx)
+	return err
+}
+
+// ListOauthConsents converts echo context to params.
+func (w *ServerInterfaceWrapper) ListOauthConsents(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListOauthConsents(ctx)
+	return err
+}
+
+// RevokeOauthConsent converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeOauthConsent(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "client_id" -------------
+	var clientId string
+
+	err = runtime.BindStyledParameter("simple", false, "client_id", ctx.Param("client_id"), &clientId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter client_id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RevokeOauthConsent(ctx, clientId)
+	return err
+}
+
+// ChangePassword converts echo context to params.
+func (w *ServerInterfaceWrapper) ChangePassword(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ChangePassword(ctx)
+	return err
+}
+
+// ForgotPassword converts echo context to params.
+func (w *ServerInterfaceWrapper) ForgotPassword(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ForgotPassword(ctx)
+	return err
+}
+
+// ResetPassword converts echo context to params.
+func (w *ServerInterfaceWrapper) ResetPassword(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ResetPassword(ctx)
+	return err
+}
+
+// RegisterUser converts echo context to params.
+func (w *ServerInterfaceWrapper) RegisterUser(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RegisterUser(ctx)
+	return err
+}
+
+// ListSessions converts echo context to params.
+func (w *ServerInterfaceWrapper) ListSessions(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListSessions(ctx)
+	return err
+}
+
+// DeleteSession converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteSession(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteSession(ctx, id)
+	return err
+}
+
+// RefreshToken converts echo context to params.
+func (w *ServerInterfaceWrapper) RefreshToken(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RefreshToken(ctx)
+	return err
+}
+
+// VerifyEmail converts echo context to params.
+func (w *ServerInterfaceWrapper) VerifyEmail(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.VerifyEmail(ctx)
+	return err
+}
+
+// ResendEmailVerification converts echo context to params.
+func (w *ServerInterfaceWrapper) ResendEmailVerification(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	ctx.Set("apiKeyAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ResendEmailVerification(ctx)
+	return err
+}
+
+// GetUserinfo converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUserinfo(ctx echo.Context) error {
+	var err error
+
+	ctx.Set("bearerAuth.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetUserinfo(ctx)
 	return err
 }
 
@@ -121,24 +1637,158 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/admin/oauth/clients", wrapper.ListOauthClients)
+	router.POST(baseURL+"/admin/oauth/clients", wrapper.CreateOauthClient)
+	router.DELETE(baseURL+"/admin/oauth/clients/:client_id", wrapper.DeleteOauthClient)
+	router.GET(baseURL+"/admin/users", wrapper.ListUsers)
+	router.POST(baseURL+"/admin/users/:id/erase", wrapper.EraseUser)
+	router.POST(baseURL+"/admin/users/:id/impersonate", wrapper.ImpersonateUser)
+	router.POST(baseURL+"/admin/users/:id/reactivate", wrapper.ReactivateUser)
+	router.POST(baseURL+"/admin/users/:id/restore", wrapper.RestoreUser)
+	router.DELETE(baseURL+"/admin/users/:id/roles/:role", wrapper.RemoveUserRole)
+	router.PUT(baseURL+"/admin/users/:id/roles/:role", wrapper.AssignUserRole)
+	router.POST(baseURL+"/admin/users/:id/suspend", wrapper.SuspendUser)
+	router.GET(baseURL+"/oauth/consent", wrapper.GetOauthConsent)
+	router.POST(baseURL+"/oauth/consent", wrapper.GrantOauthConsent)
+	router.GET(baseURL+"/user/api-keys", wrapper.ListApiKeys)
+	router.POST(baseURL+"/user/api-keys", wrapper.CreateApiKey)
+	router.DELETE(baseURL+"/user/api-keys/:id", wrapper.DeleteApiKey)
+	router.GET(baseURL+"/user/api-keys/:id", wrapper.GetApiKey)
+	router.PATCH(baseURL+"/user/api-keys/:id", wrapper.UpdateApiKey)
+	router.POST(baseURL+"/user/exports", wrapper.CreateExport)
+	router.GET(baseURL+"/user/exports/:id", wrapper.GetExport)
+	router.GET(baseURL+"/user/exports/:id/archive", wrapper.DownloadExport)
+	router.DELETE(baseURL+"/user/impersonation", wrapper.StopImpersonation)
 	router.POST(baseURL+"/user/login", wrapper.LoginUser)
+	router.POST(baseURL+"/user/login/magic-link", wrapper.SendMagicLink)
+	router.POST(baseURL+"/user/login/magic-link/redeem", wrapper.RedeemMagicLink)
+	router.POST(baseURL+"/user/login/mfa", wrapper.LoginUserMfa)
+	router.POST(baseURL+"/user/logout", wrapper.LogoutUser)
+	router.POST(baseURL+"/user/logout/all", wrapper.LogoutAllSessions)
+	router.DELETE(baseURL+"/user/me", wrapper.DeleteMe)
+	router.GET(baseURL+"/user/me", wrapper.GetMe)
+	router.PATCH(baseURL+"/user/me", wrapper.UpdateMe)
+	router.DELETE(baseURL+"/user/me/avatar", wrapper.DeleteAvatar)
+	router.PUT(baseURL+"/user/me/avatar", wrapper.UploadAvatar)
+	router.POST(baseURL+"/user/me/email", wrapper.ChangeEmail)
+	router.POST(baseURL+"/user/me/email/confirm", wrapper.ConfirmEmailChange)
+	router.POST(baseURL+"/user/me/mobile", wrapper.ChangeMobile)
+	router.POST(baseURL+"/user/me/mobile/confirm", wrapper.ConfirmMobileChange)
+	router.POST(baseURL+"/user/mfa/recovery-codes", wrapper.RegenerateRecoveryCodes)
+	router.POST(baseURL+"/user/mfa/totp", wrapper.EnrollTotp)
+	router.POST(baseURL+"/user/mfa/totp/confirm", wrapper.ConfirmTotp)
+	router.POST(baseURL+"/user/mfa/totp/disable", wrapper.DisableTotp)
+	router.GET(baseURL+"/user/oauth/consents", wrapper.ListOauthConsents)
+	router.DELETE(baseURL+"/user/oauth/consents/:client_id", wrapper.RevokeOauthConsent)
+	router.POST(baseURL+"/user/password", wrapper.ChangePassword)
+	router.POST(baseURL+"/user/password/forgot", wrapper.ForgotPassword)
+	router.POST(baseURL+"/user/password/reset", wrapper.ResetPassword)
 	router.POST(baseURL+"/user/register", wrapper.RegisterUser)
+	router.GET(baseURL+"/user/sessions", wrapper.ListSessions)
+	router.DELETE(baseURL+"/user/sessions/:id", wrapper.DeleteSession)
+	router.POST(baseURL+"/user/token/refresh", wrapper.RefreshToken)
+	router.POST(baseURL+"/user/verify-email", wrapper.VerifyEmail)
+	router.POST(baseURL+"/user/verify-email/resend", wrapper.ResendEmailVerification)
+	router.GET(baseURL+"/userinfo", wrapper.GetUserinfo)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RVUW/TQAz+K5XhMWqy8YLyNiRAAyRQN8RDVaFr4qY3krub7QyqKf8d3V3aZmq6IrEJ",
-	"8dRr7Hz+bH/35R4K2zhr0AhDfg9crLFR4fiWyJI/OLIOSTSGx4Ut0f/KxiHkoI1ghQRdAg0yq2oYZCFt",
-	"Kui6BAhvW01YQj6PEPv8RbLNt8sbLMRjXYmSlg+r/3GNx8C/MtInW2kzw9sWWQ7LYKN0PVIkAaeYf1oq",
-	"TzOIGIM3TlBhZw3jIZeVJpbvRjU4SqhWj0XF/kBzmmtMS4a1hsjHmM+w0iykRNvjs1RlScg8Su/4nP+q",
-	"68YudY1PtL8jMxlA7Qomu2YPJ9YlwFi0pGVz5S9ZHM4SFSFdtLLe/3tnqVECOXz4dg1JvJIeKUZhh7wW",
-	"cdB5YG1WNjSkxbcN7+3k4svl5BobVyvxvO6QWFsDOZxNs2nmJ2EdGuU05PBqmk3PQ0OyDqzSlpHS2usy",
-	"7NPGvZbIBWknESjIduJlAAEsyuCy3Ib6CEVdvLHlJtqHETQBTjlX6yK8ld6wNXv/8aeXhCvI4UW6N6i0",
-	"d6f04AKHKYzR2yYMVyvUYth1vHGh4/MsezJ20ThHKH3+COHZSrW1PH+51uAvh4VgOcE+Z69ByOdeo6pi",
-	"r/Wwq4WPx9VTuNhIx7c/6zPGBbCNPrMGxvxnZBDDtH+iiP5r9l9K4mHCQ8eaL7qFD5O3lxBtqe6dKU/T",
-	"2haqXluW/HWWZalyOr07g27R/Q4AAP//mt8va3YIAAA=",
+	"H4sIAAAAAAAC/+w9XXPbNrZ/BcN7H2nL7e7O3PWb12k73iabXCdp70wno4HIIwk1CbAAaEXJ+L/fOQBI",
+	"kSJAUralxLaeEosgAJ7vLxx8jRKRF4ID1yo6/xqpZAk5Nf+9SBJRcv0KMtBwDX+VoDT+XkhRgNQMzKiC",
+	"KrUSMsX/63UB0XmktGR8Ed3dxZGEv0omIY3O/9iM/BRXI8XsT0h0dBdHF2nO+EWimeDBpSRQJTj+LwWV",
+	"SFbg6Og8+n25JnoJhJrXCVNE0xvgMbmBQhPG7cMyZZpkYhHFA/t0ywR3+VGB7G4OF7+FBhRmQmRAOb6W",
+	"SKAa0ik1HzUXMsf/RSnVcKJZDt0txVFqwL7bO5BTlnkQ4Z5Mb0GyOYPUv8s5k0pPOc3BOwVrvsa4hgVI",
+	"/D2jfW/lYsYy/yMpMgs6piFX3iHuByolXXfwxNKo+uR6ndZXNPcWVwjqwKLaSAtNXuQX7FdYdzF/H+zC",
+	"54JJUDu904uAUu24gyDGCglz9rnLZe81lZqIueGmG1jHRAsiIRELzr4AYdq3ikpE8XAkOwy6ndWzjsTY",
+	"pRkUlCr3QUUQeJvvbQPvHcicKcUEVy0QUqLKmYIarkV3XKlARvF94edA5/Y1DCRVCK7AI94KNr2x1P/f",
+	"EubRefRfk43mmDi1MbGT4bRu8BYNAU8Jq2Xy/51cvLs6+RXWZAk0BRmbZ4pwoYlaihUndEEZH5TY1ebs",
+	"quGPfM18BODeblPpuI/sBX09b3hDH4u0jzRHkNkOZNHZw6Xgmib6ckn5Ai4FnzOZB/eSiBSGdbwZ5fve",
+	"n1Do2pWCS4TV13gjo9IHvcaG2cxvqADWwc1ocQN8eD07zLuIlEIOwrEhx3NQii7GAnkz3rv450JILxbz",
+	"Yne74uEari0GPqA9JpMluwWSUE5mQFKx4pmgKaSk5JplKB+Q8cctVo36GgEvc4TRn0rg619YEX3yvMBS",
+	"P19pqkvVnKcAnuJD1D4iAaXsHxJoivJmTlkGqWcJnxpzu6yXGdRfFotBEt3xq7e25N72rfszgywdoN82",
+	"Rq/LDIxIn+OrZCbFDZyTajk0FcRULYXU9r+Z4Av3I0OCjFHxKcFpNmV8LoiQZCaBJktjn3XRjYv4zc2x",
+	"PGSniMfw0lXuNvcg5wTVOOo2Vs+GcIHTxakxAgrENNEsuQH9EA9la7MhdV5xJ+N+WRSSfnGE3zH1m6Je",
+	"0Rg319q87tv7a7EQpe6B8FyCWk57JHNnyjd0wZLXjN9cQwqQ70/aNxbaUbt5FZh3iTkdUKABHd3Wn23y",
+	"vCylBK5JNWLL6hyliHp1Le5apLAvu+LNnL4WC8Z3h0k+p9ORaN8MjXt2YjzQAQz1uMPjbZza1+0F/Fta",
+	"6uVlxoD7oGJ+nwbUYIK2YApcM5o9XkQjaM1KSJmERE9LyXYyah/Bv9wAonYzW1+/vbvxzmcD/gMe6Da4",
+	"tzi08ZTY7SqyAI1qAxKJ/+Epyekaedbwrh1EEgnuNUUWkvKGXmlgcr9Y2XL/zO/NPeK2qbohcyFj/H1N",
+	"cloQwbVousIPdn/DOGyCfiQWQzo1qXmtz3lsTGgYydKfxeQIOWhfHNip38+17453c7d2OoKNVM/GEGb3",
+	"kET2aZBIDV1D6ncxjMlFM2Ork8RuAFIMW8EtyDUxVGDIzliqoHTljdCikOIWUrJieilKjTRqDf8uAz2q",
+	"CGp+b4NMq88cAm8A8fbhjpj/Bdcc3nw199DWgtLPwTqgZwb0VArTZEmzDPgCRgyZ5qCXwj8ZFzzxeDb/",
+	"wZ/RKqKcvC2AX70il4JzSHRFNDGBZCkgraJaV69IZSz0CtWA1LXiZWqfhGI/Qe91hC3VXiJu0V9rfx0A",
+	"h8AZ10gMUoGlph2535H9TlbGo7LjNgP26vprBzuf5+KgqoXPQQQJKJQUBkdrV3FGkxv8daMsR7iFm2V8",
+	"u3znzMUhL8K6BNMegzSOOKym4y3WzpRbE/Tt9mchF0Lvz62q1rkGBeFlBr433s2DHPH515AI1FLoPCm/",
+	"W+OxsN5yIMgZxDx3JhWV4MLpgmdrYuTcvS0qu7Bvx+/BWGuPkyBzFONlF70E2dLaK6pITlMgcylyopdM",
+	"EeU249PYAWnDCu/PJr2mAPhO+zdxDrpwnzAiONh4wWxlA4KWp7G1nx48+G0BB5fxtkCF1SG6qCf2bqmO",
+	"q265w2PDdX3xuQ/LMp9xJwW2vpZ98aj031mql8ZrWgJbLE06qmCfIVNR3AlqxVEpR8gXs5Id690jsv21",
+	"DWA9aoDLTXzfON/QkjvLtfaEreCfHy66+IlLkWW51zsI+kVx5CyoNmqFLlAVk4/XV0alLsWKUEUo+d9r",
+	"4qJVA3i0C9rpfTvG6o8rPhe7pK9GVV/QnGXrHk+H3QIPPw4+KFiiS+l/psrZCMouZ0FA+GUMh896mpRS",
+	"CdnF0KX5vQoz4lBS0AXExDj7fEGEtaJRzJknIem6Q762rtoZEmJ23uDX9gYaD5a9bGxlw/ZbQKZSMhdp",
+	"MZxICspkjHkVYwJQTuqYJlktgdtgkuApmdNEC5OpqDcW7yhUBgqJBgqG5nS6AYmPU/oCt48o0VobCdYV",
+	"hRD0Toq5i/RuubtpKkGpsJzYW/1WThcwdcqsTS6vqVyA0kRXmrRiT3pLNZUxgbzQ6zoYIriXKQ9XCBZH",
+	"9U7HS4GNmTAkBXaoKXPIbEK3tblgtVmIbq5hwZSW/bnG+9HQw9jyMRIXFWCD8Gz4hzXwq48NQWyghqYP",
+	"Vg+AiM8W+41mLDWYC+TOTdJ5PMU20vAeFtjZdo6r9buQvIsjBUkpmV6/x8XrwqhfYX1R6qXZMo/OI1un",
+	"VeVKzqO6gmsjEGhdHTUDKkFW79u/fq68p3///sEEWHC16Nw93cyy1LqI7nBjzBlbmmkkwOgXQS7eXZEP",
+	"kBcZ1fhZtyCt4xn9cHp2eoZLiwI4LVh0Hv3t9Oz0R0Naemm+akLRHJgItBMnjZj4AjzepmVHkJCSt/gh",
+	"VQImMktYNr1KUYQypRsBcxVtQnlm9h/PzlwoVjtDlxZFxhIzw+RPVzpgMb9DYB6XtWDaigP8amuI57TM",
+	"9KMt7Gixu1zJ4XMBCUbXwY3ZUFR0/sfXFi38ERkURJ/u4jaRbR4gidKFwp8uqrFRIVQPikyAtoEjG9h3",
+	"KTKM35Kkm0lDO8dYRBJ0KTmkVXSkjV2b+mnAPbIsBkr/S6TrfWC2nTP0odh8qx1M7GhSDW8KAC1LuDsM",
+	"NW5lyF4GXd7FXpEy+VoHku8s0WagPaa6PV6xTbyEYomUsbhsBNilWYhxA9G1ZbpDpXaqbSrdE9pdSOfF",
+	"yB4qaQ7auJ1/OGWIKmWjCttplCb3xY1P3FbVnzbkUzu1Xk2Epg5GOXWyRC/Z1tpluCGsIkdHmVCsCdAs",
+	"h1OCQW3S8MNtOoEqYv+MN5SlaA5ECWnLCIQ0ZdhzIdvu+alX4Zk9RX7g/FWCXG+gU5++6ICi9mDuYv+r",
+	"dfRzrkG2ZhgTih2adQZzIeFe026V+JvTCZXnVJm6vqWrZ2GqGJjc2K3ozJsgibOdfSvVeeSehXzvMZ5k",
+	"ZQpTdwjpXmhDmmq9WEuEdjy7ql1t/9jvKXwajWZD0IFtUJU01rd/Idh3mD5jOQt85T/O4iinn1mO0/94",
+	"hn8xbv/6IfbUTgbI1PBrLw4/7VHK10G+l6bLjTCefGXp3QQkdaH1QR0wTvhvkP4pZNRecMHXOZ6rcqeC",
+	"THE0Samm1pY1OWIU05RIk8PQguBGS1mnxU6JqbO3pzjR0DXHIWelJiUvFZ1h2TXTdapMGQUApuRcmZyh",
+	"hNwUwVCeNo5QAtcmtkhnGAsyZyoVEOdmg50lBY3hj1Pyu2RaW4OldQizq09+Qih/tFWn+zCtPQdMPURm",
+	"RhE77JtY00/erOpyVRx9PlHAFbMHZA0EvYzWKI0/MLtdKVUCxsCXaAhl7NaUiWFwHO0WvrA5JNzqKXlb",
+	"kS1aY/IGUlJ/nOOaeanM+4TpmChNpZ2Cp0RpURTmDwlkNZo3NkX9++QQ7zkHD2W0xn0TJvEfcniRPCPB",
+	"WNaHZ5nXtgK5VAVwc2oLFVImFoS5U5u7CP/r+iuOGuAZaoAQ6Sot5KHp9iNPLTka3waRbGs7KzsJHWNX",
+	"yOk2SFaMp2K1Gz2bN4/E/HKIWWSgJl/xn95w3wd6g4YGjiN0Rde2ZM3aFnFVl24N8uZRLDTfM7FYYIC6",
+	"1B56Q0vd5A9FBsew3wHDfrsKptg7i6zQNjpuGEdF6RFvps66IjAtHGV1COZCKbbgR4I5RPzA2UgH1nPv",
+	"7aq1aNFLYNI6NZV3fytuNt79Gs++cKErG86ewt+Yl+ku+s8tftR/z1X/uVTX5lCXN2dxuQTMOnCCo4Vk",
+	"X6zLWJVtI+WtGuXcruC559yWJ+n1C+jWAbNR2YjtIzDj5W4oTHuv5E8c2l3rEM5O820JgYImxtLFMDWk",
+	"MaFZZo+91e2FXL7RFAGKHBk8DSQTzHv3ySZ0ThE9HNyBY0g76c89Z8QdPT451m/xvMlJ9xRfXNhzX5iL",
+	"SoGvw7w+F10e7/IyGi9b3Ly/Mov2ucRwhYUd9+1qK+pzZc+ClEKRLSSICS3YSdWKy6tRsPILB9TSq4+c",
+	"MHFl+2zttRyr0VvsaWPImGthXnc1RpQTh4W46mO3UxWVBde+zEJPxz+fXfjuimDbuW9YNuVtu/cc6GcU",
+	"gxvPqC9Ocm28kwaxBaqdGtR0NOHvg5o4IGhrwI8Stb+A3j8m3ArPRcjuJRaA9WE+bsIp0UqTYMz4lhR3",
+	"Zr/t3WaDAxnMsUxAi9L1YGuj29bcH0CSt4v7eyS5HfgNJfnzl92uNAS3F3AGbCMfU2ldGLlh49kFA9tC",
+	"w5SvLLFBoC0e2RYrp6TZGZIpQpWCfJZtemtgGeNCipKnMSlEZtpEupoVF7GyHWRN7OA0YIG45pj7odt2",
+	"z0YPluyAb0Kp7sNfCqXWRoZXw1m9bqupDGE6Mhqp7moieiw5fpDQxDOiAD+6J056BNH+yjWatSFsJ2oM",
+	"Edh4Y4MUuiane/f7xX33XFdz/BdWtIfXlc4zxqlce8qcO4hrSGgsPP/3+7f/IalIyhyZxQj/L6xAgJon",
+	"eAZWkRRcG93qcLddF7tqodkh5BMjvV7hw5rFSSNcHIRH6x1XeWaKyKqwmay1xVaiRYuiVQ119IR2FB4Z",
+	"nqQP2zTmoD356A0v4aM9prg6LQc8kLDb+xa2RLcLQZAu/v7jP/dPEx+EIDnla2JbchOqNeSFtj2Q6uoe",
+	"IatKZVtRkdwY78YeazUwugYt1ycX5qxJ12YwPRIUpqJWlGliD48QLdemqNNdVtDjot19T2wyxBWTHJsa",
+	"n2SM34QZ5A22C6AEW3dkplaFmHeJeasjroCndafkPbFNpxOzBzJmDMFB32VW+LAMg5hSlYZp6JzqDM6R",
+	"OQaYYyJNc/FeJYIObH3mzip4Y3T2MIttWX5Adml2SB9iGhz7veudJ0RLcxqmnkt3Z0dNLYaOKPnw9sM7",
+	"G9KznQKrBlcBK+XNnO6LhLZ6sPto5+cL8iRslSdCM6LUYYJpOBZVFIMmCShVnWnBg75mMM2ytTkD5rom",
+	"1Y1rOzQkSr1HU7d984PfzsWAYT3i6OXs7OWIUk9olg2SjY3ZOoJBUmnRxrjEu1ntIsveQ93A/YivB8YV",
+	"chjRRqLl68w9VXU8NQWeyEytYvNTYsr7lOmAXh18YNrF81dLhqdFzQl8opdUuzA7SFpFKRZCpKeBJO0b",
+	"2FeSyHdFqS9L5EDioHSsBN0m03vniV2fu7FR8zd7rTVvNt57EoC+C2ZsXT4TYVqEQXz/3O3eWLLbks0D",
+	"mm+YrX0CNHJPHZ/DxPZr7A8640mhRnPHUZzram3s7Efk7FTmUXpNrSKjyRAaKkfvzwIWMSkwfSLJgs2J",
+	"aTZ5Sq6MDk6kKApbHk+J+qtEEWDOmic0q36ew6rR3BN7VatTj2DA3FYDySHhkJeZZgWVeoJ5nBPMmLWh",
+	"vd310DaOHE42bV/Rl/n6q3q0u4Wg3f/3J0z+/sPfDhDNM1kk7AKFcW0qF9ZQ41jxL1aQ2o384zAbMerK",
+	"3eMbIt+nLGbrHquBo1f23FVib9S1OT2MzVhe5LCyoVVvTbwtgbHPq6raxNzXYYtq7Qs4GVPVCuAxvO0d",
+	"Hz+5EO5eCl26N/r6ql3Ml9hh32es/eyfh2GKGqfVySaT4J3hmQlhjkA5v+15+ayWVyaOUvuCDxuNaCEV",
+	"VIj466aewF3gteEqA0SFHVsaTZe3mMPupkHBe+KRvhu2Pdi4bEqMS5FW2/qelNlh2QVvdtEU405dRqlq",
+	"AnOg3DTpe8IaZdNe288eH7ARY69KsTMQXuYz1xnMr1rcgIfrljdVg+69pBQ8l6n60gr2o4/qBSr8vzj9",
+	"Yr97NwXT5pWxiiakRpq0etQj35secbh+GYpkTidVNvikviFumB9aGeRxZyuvYQEcf4D2RXV7yzAP6gJc",
+	"3wQ+qgv9vok+aEPj+Sel5nSihS56qhfcqVFbruDa8Y86DW7vBcMbwvYZcty6gezFYGxYY/7EsUcs0Stx",
+	"4i6FwtPowLX74Co8abtRe4tPnCKqcbgfySDSQblwFAWHI6yUmebCYcJ6xVQ/ZXXzD/aNPdPR09AwL6Xe",
+	"odViKNwRwt230zXh3ZXNm9Knnjt7qkUO1BTmGfSKqBrDBNA19vaTZqmafdXrjpkoR30L9rYlilN0usYc",
+	"ue9erVj2fNOJIZXmhXEBo9EGdIwDXslab/lDu2Fmf5NMO2klu/ekR/x3rHvwV+uQ7zp49XhrbV+OF3DX",
+	"HU7ITKJcuK1fIrLMQD0n/VYRNibRF6KnnNem0OiGEyQo0P4zA/ay/APRePtm/j4atyOPZ20aqAueuMEs",
+	"ubvHvr5W43gKpxvsqvnHwDTMPu9YcuNyJDUHOc/VYsPUNe+mSq7xxQNxmVlrDJOZgUc98uT0SA+NS3e3",
+	"ZV8Y147wH1Gunu75lLLv0mIPIJrDjnT6nOhUVec8gl034JYl4PHSN3KWceKJ/KCrfJBTJHaN59PFsYOb",
+	"wZZ779mCYy8cgyqvG+5ThbY02IHvqbXDeSmxNGPiTNxBrh5P47MtBzGmUfPQlz0FhCaU/bugTJ6Sf0mx",
+	"QrJwN96SEpsAkESIGwYmoksUVkKyOsFaTeqGMK400NR3fYoZ98EdRtyH2jJzu3V6VZbd8SEOHrotPYcT",
+	"qqbybn0yUCfrskPYi9BVe7UPyOe2k4e/7/tvZom9F7jaZQYLXO2wYz7gEejF+HM8Heo44ko4zJtVQhL9",
+	"6up2ioHqBVxig+BN3ul4/q+JGMbnImjTvS2AX73C/ugcEk2SjLJcNTo7WpOhulxrc/y85nJRAGcpqe4V",
+	"6BwW/FjtYM/FSle4xrOJ6JtEzIkBqnlkoYxIHZhu+6Ibs4ACeVvZcqXMovNoqXVxPplkIqHZUih9/j9n",
+	"Z2fY2Hly+0N09+nu/wcApHuZLlKwAAA=",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
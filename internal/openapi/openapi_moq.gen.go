@@ -9,8 +9,59 @@ import (
 )
 
 var (
-	lockServerInterfaceMockLoginUser    sync.RWMutex
-	lockServerInterfaceMockRegisterUser sync.RWMutex
+	lockServerInterfaceMockAssignUserRole          sync.RWMutex
+	lockServerInterfaceMockChangeEmail             sync.RWMutex
+	lockServerInterfaceMockChangeMobile            sync.RWMutex
+	lockServerInterfaceMockChangePassword          sync.RWMutex
+	lockServerInterfaceMockConfirmEmailChange      sync.RWMutex
+	lockServerInterfaceMockConfirmMobileChange     sync.RWMutex
+	lockServerInterfaceMockConfirmTotp             sync.RWMutex
+	lockServerInterfaceMockCreateApiKey            sync.RWMutex
+	lockServerInterfaceMockCreateExport            sync.RWMutex
+	lockServerInterfaceMockCreateOauthClient       sync.RWMutex
+	lockServerInterfaceMockDeleteApiKey            sync.RWMutex
+	lockServerInterfaceMockDeleteAvatar            sync.RWMutex
+	lockServerInterfaceMockDeleteMe                sync.RWMutex
+	lockServerInterfaceMockDeleteOauthClient       sync.RWMutex
+	lockServerInterfaceMockDeleteSession           sync.RWMutex
+	lockServerInterfaceMockDisableTotp             sync.RWMutex
+	lockServerInterfaceMockDownloadExport          sync.RWMutex
+	lockServerInterfaceMockEnrollTotp              sync.RWMutex
+	lockServerInterfaceMockEraseUser               sync.RWMutex
+	lockServerInterfaceMockForgotPassword          sync.RWMutex
+	lockServerInterfaceMockGetApiKey               sync.RWMutex
+	lockServerInterfaceMockGetExport               sync.RWMutex
+	lockServerInterfaceMockGetMe                   sync.RWMutex
+	lockServerInterfaceMockGetOauthConsent         sync.RWMutex
+	lockServerInterfaceMockGetUserinfo             sync.RWMutex
+	lockServerInterfaceMockGrantOauthConsent       sync.RWMutex
+	lockServerInterfaceMockImpersonateUser         sync.RWMutex
+	lockServerInterfaceMockListApiKeys             sync.RWMutex
+	lockServerInterfaceMockListOauthClients        sync.RWMutex
+	lockServerInterfaceMockListOauthConsents       sync.RWMutex
+	lockServerInterfaceMockListSessions            sync.RWMutex
+	lockServerInterfaceMockListUsers               sync.RWMutex
+	lockServerInterfaceMockLoginUser               sync.RWMutex
+	lockServerInterfaceMockLoginUserMfa            sync.RWMutex
+	lockServerInterfaceMockLogoutAllSessions       sync.RWMutex
+	lockServerInterfaceMockLogoutUser              sync.RWMutex
+	lockServerInterfaceMockReactivateUser          sync.RWMutex
+	lockServerInterfaceMockRedeemMagicLink         sync.RWMutex
+	lockServerInterfaceMockRefreshToken            sync.RWMutex
+	lockServerInterfaceMockRegenerateRecoveryCodes sync.RWMutex
+	lockServerInterfaceMockRegisterUser            sync.RWMutex
+	lockServerInterfaceMockRemoveUserRole          sync.RWMutex
+	lockServerInterfaceMockResendEmailVerification sync.RWMutex
+	lockServerInterfaceMockResetPassword           sync.RWMutex
+	lockServerInterfaceMockRestoreUser             sync.RWMutex
+	lockServerInterfaceMockRevokeOauthConsent      sync.RWMutex
+	lockServerInterfaceMockSendMagicLink           sync.RWMutex
+	lockServerInterfaceMockStopImpersonation       sync.RWMutex
+	lockServerInterfaceMockSuspendUser             sync.RWMutex
+	lockServerInterfaceMockUpdateApiKey            sync.RWMutex
+	lockServerInterfaceMockUpdateMe                sync.RWMutex
+	lockServerInterfaceMockUploadAvatar            sync.RWMutex
+	lockServerInterfaceMockVerifyEmail             sync.RWMutex
 )
 
 // Ensure, that ServerInterfaceMock does implement ServerInterface.
@@ -23,12 +74,165 @@ var _ ServerInterface = &ServerInterfaceMock{}
 //
 //         // make and configure a mocked ServerInterface
 //         mockedServerInterface := &ServerInterfaceMock{
+//             AssignUserRoleFunc: func(ctx echo.Context, id int, role string) error {
+// 	               panic("mock out the AssignUserRole method")
+//             },
+//             ChangeEmailFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ChangeEmail method")
+//             },
+//             ChangeMobileFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ChangeMobile method")
+//             },
+//             ChangePasswordFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ChangePassword method")
+//             },
+//             ConfirmEmailChangeFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ConfirmEmailChange method")
+//             },
+//             ConfirmMobileChangeFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ConfirmMobileChange method")
+//             },
+//             ConfirmTotpFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ConfirmTotp method")
+//             },
+//             CreateApiKeyFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the CreateApiKey method")
+//             },
+//             CreateExportFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the CreateExport method")
+//             },
+//             CreateOauthClientFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the CreateOauthClient method")
+//             },
+//             DeleteApiKeyFunc: func(ctx echo.Context, id int) error {
+// 	               panic("mock out the DeleteApiKey method")
+//             },
+//             DeleteAvatarFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the DeleteAvatar method")
+//             },
+//             DeleteMeFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the DeleteMe method")
+//             },
+//             DeleteOauthClientFunc: func(ctx echo.Context, clientId string) error {
+// 	               panic("mock out the DeleteOauthClient method")
+//             },
+//             DeleteSessionFunc: func(ctx echo.Context, id string) error {
+// 	               panic("mock out the DeleteSession method")
+//             },
+//             DisableTotpFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the DisableTotp method")
+//             },
+//             DownloadExportFunc: func(ctx echo.Context, id string) error {
+// 	               panic("mock out the DownloadExport method")
+//             },
+//             EnrollTotpFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the EnrollTotp method")
+//             },
+//             EraseUserFunc: func(ctx echo.Context, id int) error {
+// 	               panic("mock out the EraseUser method")
+//             },
+//             ForgotPasswordFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ForgotPassword method")
+//             },
+//             GetApiKeyFunc: func(ctx echo.Context, id int) error {
+// 	               panic("mock out the GetApiKey method")
+//             },
+//             GetExportFunc: func(ctx echo.Context, id string) error {
+// 	               panic("mock out the GetExport method")
+//             },
+//             GetMeFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the GetMe method")
+//             },
+//             GetOauthConsentFunc: func(ctx echo.Context, params GetOauthConsentParams) error {
+// 	               panic("mock out the GetOauthConsent method")
+//             },
+//             GetUserinfoFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the GetUserinfo method")
+//             },
+//             GrantOauthConsentFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the GrantOauthConsent method")
+//             },
+//             ImpersonateUserFunc: func(ctx echo.Context, id int) error {
+// 	               panic("mock out the ImpersonateUser method")
+//             },
+//             ListApiKeysFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ListApiKeys method")
+//             },
+//             ListOauthClientsFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ListOauthClients method")
+//             },
+//             ListOauthConsentsFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ListOauthConsents method")
+//             },
+//             ListSessionsFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ListSessions method")
+//             },
+//             ListUsersFunc: func(ctx echo.Context, params ListUsersParams) error {
+// 	               panic("mock out the ListUsers method")
+//             },
 //             LoginUserFunc: func(ctx echo.Context) error {
 // 	               panic("mock out the LoginUser method")
 //             },
+//             LoginUserMfaFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the LoginUserMfa method")
+//             },
+//             LogoutAllSessionsFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the LogoutAllSessions method")
+//             },
+//             LogoutUserFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the LogoutUser method")
+//             },
+//             ReactivateUserFunc: func(ctx echo.Context, id int) error {
+// 	               panic("mock out the ReactivateUser method")
+//             },
+//             RedeemMagicLinkFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the RedeemMagicLink method")
+//             },
+//             RefreshTokenFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the RefreshToken method")
+//             },
+//             RegenerateRecoveryCodesFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the RegenerateRecoveryCodes method")
+//             },
 //             RegisterUserFunc: func(ctx echo.Context) error {
 // 	               panic("mock out the RegisterUser method")
 //             },
+//             RemoveUserRoleFunc: func(ctx echo.Context, id int, role string) error {
+// 	               panic("mock out the RemoveUserRole method")
+//             },
+//             ResendEmailVerificationFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ResendEmailVerification method")
+//             },
+//             ResetPasswordFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the ResetPassword method")
+//             },
+//             RestoreUserFunc: func(ctx echo.Context, id int) error {
+// 	               panic("mock out the RestoreUser method")
+//             },
+//             RevokeOauthConsentFunc: func(ctx echo.Context, clientId string) error {
+// 	               panic("mock out the RevokeOauthConsent method")
+//             },
+//             SendMagicLinkFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the SendMagicLink method")
+//             },
+//             StopImpersonationFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the StopImpersonation method")
+//             },
+//             SuspendUserFunc: func(ctx echo.Context, id int) error {
+// 	               panic("mock out the SuspendUser method")
+//             },
+//             UpdateApiKeyFunc: func(ctx echo.Context, id int) error {
+// 	               panic("mock out the UpdateApiKey method")
+//             },
+//             UpdateMeFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the UpdateMe method")
+//             },
+//             UploadAvatarFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the UploadAvatar method")
+//             },
+//             VerifyEmailFunc: func(ctx echo.Context) error {
+// 	               panic("mock out the VerifyEmail method")
+//             },
 //         }
 //
 //         // use mockedServerInterface in code that requires ServerInterface
@@ -36,55 +240,1762 @@ var _ ServerInterface = &ServerInterfaceMock{}
 //
 //     }
 type ServerInterfaceMock struct {
+	// AssignUserRoleFunc mocks the AssignUserRole method.
+	AssignUserRoleFunc func(ctx echo.Context, id int, role string) error
+
+	// ChangeEmailFunc mocks the ChangeEmail method.
+	ChangeEmailFunc func(ctx echo.Context) error
+
+	// ChangeMobileFunc mocks the ChangeMobile method.
+	ChangeMobileFunc func(ctx echo.Context) error
+
+	// ChangePasswordFunc mocks the ChangePassword method.
+	ChangePasswordFunc func(ctx echo.Context) error
+
+	// ConfirmEmailChangeFunc mocks the ConfirmEmailChange method.
+	ConfirmEmailChangeFunc func(ctx echo.Context) error
+
+	// ConfirmMobileChangeFunc mocks the ConfirmMobileChange method.
+	ConfirmMobileChangeFunc func(ctx echo.Context) error
+
+	// ConfirmTotpFunc mocks the ConfirmTotp method.
+	ConfirmTotpFunc func(ctx echo.Context) error
+
+	// CreateApiKeyFunc mocks the CreateApiKey method.
+	CreateApiKeyFunc func(ctx echo.Context) error
+
+	// CreateExportFunc mocks the CreateExport method.
+	CreateExportFunc func(ctx echo.Context) error
+
+	// CreateOauthClientFunc mocks the CreateOauthClient method.
+	CreateOauthClientFunc func(ctx echo.Context) error
+
+	// DeleteApiKeyFunc mocks the DeleteApiKey method.
+	DeleteApiKeyFunc func(ctx echo.Context, id int) error
+
+	// DeleteAvatarFunc mocks the DeleteAvatar method.
+	DeleteAvatarFunc func(ctx echo.Context) error
+
+	// DeleteMeFunc mocks the DeleteMe method.
+	DeleteMeFunc func(ctx echo.Context) error
+
+	// DeleteOauthClientFunc mocks the DeleteOauthClient method.
+	DeleteOauthClientFunc func(ctx echo.Context, clientId string) error
+
+	// DeleteSessionFunc mocks the DeleteSession method.
+	DeleteSessionFunc func(ctx echo.Context, id string) error
+
+	// DisableTotpFunc mocks the DisableTotp method.
+	DisableTotpFunc func(ctx echo.Context) error
+
+	// DownloadExportFunc mocks the DownloadExport method.
+	DownloadExportFunc func(ctx echo.Context, id string) error
+
+	// EnrollTotpFunc mocks the EnrollTotp method.
+	EnrollTotpFunc func(ctx echo.Context) error
+
+	// EraseUserFunc mocks the EraseUser method.
+	EraseUserFunc func(ctx echo.Context, id int) error
+
+	// ForgotPasswordFunc mocks the ForgotPassword method.
+	ForgotPasswordFunc func(ctx echo.Context) error
+
+	// GetApiKeyFunc mocks the GetApiKey method.
+	GetApiKeyFunc func(ctx echo.Context, id int) error
+
+	// GetExportFunc mocks the GetExport method.
+	GetExportFunc func(ctx echo.Context, id string) error
+
+	// GetMeFunc mocks the GetMe method.
+	GetMeFunc func(ctx echo.Context) error
+
+	// GetOauthConsentFunc mocks the GetOauthConsent method.
+	GetOauthConsentFunc func(ctx echo.Context, params GetOauthConsentParams) error
+
+	// GetUserinfoFunc mocks the GetUserinfo method.
+	GetUserinfoFunc func(ctx echo.Context) error
+
+	// GrantOauthConsentFunc mocks the GrantOauthConsent method.
+	GrantOauthConsentFunc func(ctx echo.Context) error
+
+	// ImpersonateUserFunc mocks the ImpersonateUser method.
+	ImpersonateUserFunc func(ctx echo.Context, id int) error
+
+	// ListApiKeysFunc mocks the ListApiKeys method.
+	ListApiKeysFunc func(ctx echo.Context) error
+
+	// ListOauthClientsFunc mocks the ListOauthClients method.
+	ListOauthClientsFunc func(ctx echo.Context) error
+
+	// ListOauthConsentsFunc mocks the ListOauthConsents method.
+	ListOauthConsentsFunc func(ctx echo.Context) error
+
+	// ListSessionsFunc mocks the ListSessions method.
+	ListSessionsFunc func(ctx echo.Context) error
+
+	// ListUsersFunc mocks the ListUsers method.
+	ListUsersFunc func(ctx echo.Context, params ListUsersParams) error
+
 	// LoginUserFunc mocks the LoginUser method.
 	LoginUserFunc func(ctx echo.Context) error
 
-	// RegisterUserFunc mocks the RegisterUser method.
-	RegisterUserFunc func(ctx echo.Context) error
+	// LoginUserMfaFunc mocks the LoginUserMfa method.
+	LoginUserMfaFunc func(ctx echo.Context) error
+
+	// LogoutAllSessionsFunc mocks the LogoutAllSessions method.
+	LogoutAllSessionsFunc func(ctx echo.Context) error
+
+	// LogoutUserFunc mocks the LogoutUser method.
+	LogoutUserFunc func(ctx echo.Context) error
+
+	// ReactivateUserFunc mocks the ReactivateUser method.
+	ReactivateUserFunc func(ctx echo.Context, id int) error
+
+	// RedeemMagicLinkFunc mocks the RedeemMagicLink method.
+	RedeemMagicLinkFunc func(ctx echo.Context) error
+
+	// RefreshTokenFunc mocks the RefreshToken method.
+	RefreshTokenFunc func(ctx echo.Context) error
+
+	// RegenerateRecoveryCodesFunc mocks the RegenerateRecoveryCodes method.
+	RegenerateRecoveryCodesFunc func(ctx echo.Context) error
+
+	// RegisterUserFunc mocks the RegisterUser method.
+	RegisterUserFunc func(ctx echo.Context) error
+
+	// RemoveUserRoleFunc mocks the RemoveUserRole method.
+	RemoveUserRoleFunc func(ctx echo.Context, id int, role string) error
+
+	// ResendEmailVerificationFunc mocks the ResendEmailVerification method.
+	ResendEmailVerificationFunc func(ctx echo.Context) error
+
+	// ResetPasswordFunc mocks the ResetPassword method.
+	ResetPasswordFunc func(ctx echo.Context) error
+
+	// RestoreUserFunc mocks the RestoreUser method.
+	RestoreUserFunc func(ctx echo.Context, id int) error
+
+	// RevokeOauthConsentFunc mocks the RevokeOauthConsent method.
+	RevokeOauthConsentFunc func(ctx echo.Context, clientId string) error
+
+	// SendMagicLinkFunc mocks the SendMagicLink method.
+	SendMagicLinkFunc func(ctx echo.Context) error
+
+	// StopImpersonationFunc mocks the StopImpersonation method.
+	StopImpersonationFunc func(ctx echo.Context) error
+
+	// SuspendUserFunc mocks the SuspendUser method.
+	SuspendUserFunc func(ctx echo.Context, id int) error
+
+	// UpdateApiKeyFunc mocks the UpdateApiKey method.
+	UpdateApiKeyFunc func(ctx echo.Context, id int) error
+
+	// UpdateMeFunc mocks the UpdateMe method.
+	UpdateMeFunc func(ctx echo.Context) error
+
+	// UploadAvatarFunc mocks the UploadAvatar method.
+	UploadAvatarFunc func(ctx echo.Context) error
+
+	// VerifyEmailFunc mocks the VerifyEmail method.
+	VerifyEmailFunc func(ctx echo.Context) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AssignUserRole holds details about calls to the AssignUserRole method.
+		AssignUserRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id int
+			// Role is the role argument value.
+			Role string
+		}
+		// ChangeEmail holds details about calls to the ChangeEmail method.
+		ChangeEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ChangeMobile holds details about calls to the ChangeMobile method.
+		ChangeMobile []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ChangePassword holds details about calls to the ChangePassword method.
+		ChangePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ConfirmEmailChange holds details about calls to the ConfirmEmailChange method.
+		ConfirmEmailChange []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ConfirmMobileChange holds details about calls to the ConfirmMobileChange method.
+		ConfirmMobileChange []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ConfirmTotp holds details about calls to the ConfirmTotp method.
+		ConfirmTotp []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// CreateApiKey holds details about calls to the CreateApiKey method.
+		CreateApiKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// CreateExport holds details about calls to the CreateExport method.
+		CreateExport []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// CreateOauthClient holds details about calls to the CreateOauthClient method.
+		CreateOauthClient []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// DeleteApiKey holds details about calls to the DeleteApiKey method.
+		DeleteApiKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id int
+		}
+		// DeleteAvatar holds details about calls to the DeleteAvatar method.
+		DeleteAvatar []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// DeleteMe holds details about calls to the DeleteMe method.
+		DeleteMe []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// DeleteOauthClient holds details about calls to the DeleteOauthClient method.
+		DeleteOauthClient []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// ClientId is the clientId argument value.
+			ClientId string
+		}
+		// DeleteSession holds details about calls to the DeleteSession method.
+		DeleteSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id string
+		}
+		// DisableTotp holds details about calls to the DisableTotp method.
+		DisableTotp []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// DownloadExport holds details about calls to the DownloadExport method.
+		DownloadExport []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id string
+		}
+		// EnrollTotp holds details about calls to the EnrollTotp method.
+		EnrollTotp []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// EraseUser holds details about calls to the EraseUser method.
+		EraseUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id int
+		}
+		// ForgotPassword holds details about calls to the ForgotPassword method.
+		ForgotPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// GetApiKey holds details about calls to the GetApiKey method.
+		GetApiKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id int
+		}
+		// GetExport holds details about calls to the GetExport method.
+		GetExport []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id string
+		}
+		// GetMe holds details about calls to the GetMe method.
+		GetMe []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// GetOauthConsent holds details about calls to the GetOauthConsent method.
+		GetOauthConsent []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Params is the params argument value.
+			Params GetOauthConsentParams
+		}
+		// GetUserinfo holds details about calls to the GetUserinfo method.
+		GetUserinfo []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// GrantOauthConsent holds details about calls to the GrantOauthConsent method.
+		GrantOauthConsent []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ImpersonateUser holds details about calls to the ImpersonateUser method.
+		ImpersonateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id int
+		}
+		// ListApiKeys holds details about calls to the ListApiKeys method.
+		ListApiKeys []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ListOauthClients holds details about calls to the ListOauthClients method.
+		ListOauthClients []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ListOauthConsents holds details about calls to the ListOauthConsents method.
+		ListOauthConsents []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ListSessions holds details about calls to the ListSessions method.
+		ListSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ListUsers holds details about calls to the ListUsers method.
+		ListUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Params is the params argument value.
+			Params ListUsersParams
+		}
+		// LoginUser holds details about calls to the LoginUser method.
+		LoginUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// LoginUserMfa holds details about calls to the LoginUserMfa method.
+		LoginUserMfa []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// LogoutAllSessions holds details about calls to the LogoutAllSessions method.
+		LogoutAllSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// LogoutUser holds details about calls to the LogoutUser method.
+		LogoutUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ReactivateUser holds details about calls to the ReactivateUser method.
+		ReactivateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id int
+		}
+		// RedeemMagicLink holds details about calls to the RedeemMagicLink method.
+		RedeemMagicLink []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// RefreshToken holds details about calls to the RefreshToken method.
+		RefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// RegenerateRecoveryCodes holds details about calls to the RegenerateRecoveryCodes method.
+		RegenerateRecoveryCodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// RegisterUser holds details about calls to the RegisterUser method.
+		RegisterUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// RemoveUserRole holds details about calls to the RemoveUserRole method.
+		RemoveUserRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id int
+			// Role is the role argument value.
+			Role string
+		}
+		// ResendEmailVerification holds details about calls to the ResendEmailVerification method.
+		ResendEmailVerification []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// ResetPassword holds details about calls to the ResetPassword method.
+		ResetPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// RestoreUser holds details about calls to the RestoreUser method.
+		RestoreUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id int
+		}
+		// RevokeOauthConsent holds details about calls to the RevokeOauthConsent method.
+		RevokeOauthConsent []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// ClientId is the clientId argument value.
+			ClientId string
+		}
+		// SendMagicLink holds details about calls to the SendMagicLink method.
+		SendMagicLink []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// StopImpersonation holds details about calls to the StopImpersonation method.
+		StopImpersonation []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// SuspendUser holds details about calls to the SuspendUser method.
+		SuspendUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id int
+		}
+		// UpdateApiKey holds details about calls to the UpdateApiKey method.
+		UpdateApiKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+			// Id is the id argument value.
+			Id int
+		}
+		// UpdateMe holds details about calls to the UpdateMe method.
+		UpdateMe []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// UploadAvatar holds details about calls to the UploadAvatar method.
+		UploadAvatar []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+		// VerifyEmail holds details about calls to the VerifyEmail method.
+		VerifyEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx echo.Context
+		}
+	}
+}
+
+// AssignUserRole calls AssignUserRoleFunc.
+func (mock *ServerInterfaceMock) AssignUserRole(ctx echo.Context, id int, role string) error {
+	if mock.AssignUserRoleFunc == nil {
+		panic("ServerInterfaceMock.AssignUserRoleFunc: method is nil but ServerInterface.AssignUserRole was just called")
+	}
+	callInfo := struct {
+		Ctx  echo.Context
+		Id   int
+		Role string
+	}{
+		Ctx:  ctx,
+		Id:   id,
+		Role: role,
+	}
+	lockServerInterfaceMockAssignUserRole.Lock()
+	mock.calls.AssignUserRole = append(mock.calls.AssignUserRole, callInfo)
+	lockServerInterfaceMockAssignUserRole.Unlock()
+	return mock.AssignUserRoleFunc(ctx, id, role)
+}
+
+// AssignUserRoleCalls gets all the calls that were made to AssignUserRole.
+// Check the length with:
+//     len(mockedServerInterface.AssignUserRoleCalls())
+func (mock *ServerInterfaceMock) AssignUserRoleCalls() []struct {
+	Ctx  echo.Context
+	Id   int
+	Role string
+} {
+	var calls []struct {
+		Ctx  echo.Context
+		Id   int
+		Role string
+	}
+	lockServerInterfaceMockAssignUserRole.RLock()
+	calls = mock.calls.AssignUserRole
+	lockServerInterfaceMockAssignUserRole.RUnlock()
+	return calls
+}
+
+// ChangeEmail calls ChangeEmailFunc.
+func (mock *ServerInterfaceMock) ChangeEmail(ctx echo.Context) error {
+	if mock.ChangeEmailFunc == nil {
+		panic("ServerInterfaceMock.ChangeEmailFunc: method is nil but ServerInterface.ChangeEmail was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockChangeEmail.Lock()
+	mock.calls.ChangeEmail = append(mock.calls.ChangeEmail, callInfo)
+	lockServerInterfaceMockChangeEmail.Unlock()
+	return mock.ChangeEmailFunc(ctx)
+}
+
+// ChangeEmailCalls gets all the calls that were made to ChangeEmail.
+// Check the length with:
+//     len(mockedServerInterface.ChangeEmailCalls())
+func (mock *ServerInterfaceMock) ChangeEmailCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockChangeEmail.RLock()
+	calls = mock.calls.ChangeEmail
+	lockServerInterfaceMockChangeEmail.RUnlock()
+	return calls
+}
+
+// ChangeMobile calls ChangeMobileFunc.
+func (mock *ServerInterfaceMock) ChangeMobile(ctx echo.Context) error {
+	if mock.ChangeMobileFunc == nil {
+		panic("ServerInterfaceMock.ChangeMobileFunc: method is nil but ServerInterface.ChangeMobile was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockChangeMobile.Lock()
+	mock.calls.ChangeMobile = append(mock.calls.ChangeMobile, callInfo)
+	lockServerInterfaceMockChangeMobile.Unlock()
+	return mock.ChangeMobileFunc(ctx)
+}
+
+// ChangeMobileCalls gets all the calls that were made to ChangeMobile.
+// Check the length with:
+//     len(mockedServerInterface.ChangeMobileCalls())
+func (mock *ServerInterfaceMock) ChangeMobileCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockChangeMobile.RLock()
+	calls = mock.calls.ChangeMobile
+	lockServerInterfaceMockChangeMobile.RUnlock()
+	return calls
+}
+
+// ChangePassword calls ChangePasswordFunc.
+func (mock *ServerInterfaceMock) ChangePassword(ctx echo.Context) error {
+	if mock.ChangePasswordFunc == nil {
+		panic("ServerInterfaceMock.ChangePasswordFunc: method is nil but ServerInterface.ChangePassword was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockChangePassword.Lock()
+	mock.calls.ChangePassword = append(mock.calls.ChangePassword, callInfo)
+	lockServerInterfaceMockChangePassword.Unlock()
+	return mock.ChangePasswordFunc(ctx)
+}
+
+// ChangePasswordCalls gets all the calls that were made to ChangePassword.
+// Check the length with:
+//     len(mockedServerInterface.ChangePasswordCalls())
+func (mock *ServerInterfaceMock) ChangePasswordCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockChangePassword.RLock()
+	calls = mock.calls.ChangePassword
+	lockServerInterfaceMockChangePassword.RUnlock()
+	return calls
+}
+
+// ConfirmEmailChange calls ConfirmEmailChangeFunc.
+func (mock *ServerInterfaceMock) ConfirmEmailChange(ctx echo.Context) error {
+	if mock.ConfirmEmailChangeFunc == nil {
+		panic("ServerInterfaceMock.ConfirmEmailChangeFunc: method is nil but ServerInterface.ConfirmEmailChange was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockConfirmEmailChange.Lock()
+	mock.calls.ConfirmEmailChange = append(mock.calls.ConfirmEmailChange, callInfo)
+	lockServerInterfaceMockConfirmEmailChange.Unlock()
+	return mock.ConfirmEmailChangeFunc(ctx)
+}
+
+// ConfirmEmailChangeCalls gets all the calls that were made to ConfirmEmailChange.
+// Check the length with:
+//     len(mockedServerInterface.ConfirmEmailChangeCalls())
+func (mock *ServerInterfaceMock) ConfirmEmailChangeCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockConfirmEmailChange.RLock()
+	calls = mock.calls.ConfirmEmailChange
+	lockServerInterfaceMockConfirmEmailChange.RUnlock()
+	return calls
+}
+
+// ConfirmMobileChange calls ConfirmMobileChangeFunc.
+func (mock *ServerInterfaceMock) ConfirmMobileChange(ctx echo.Context) error {
+	if mock.ConfirmMobileChangeFunc == nil {
+		panic("ServerInterfaceMock.ConfirmMobileChangeFunc: method is nil but ServerInterface.ConfirmMobileChange was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockConfirmMobileChange.Lock()
+	mock.calls.ConfirmMobileChange = append(mock.calls.ConfirmMobileChange, callInfo)
+	lockServerInterfaceMockConfirmMobileChange.Unlock()
+	return mock.ConfirmMobileChangeFunc(ctx)
+}
+
+// ConfirmMobileChangeCalls gets all the calls that were made to ConfirmMobileChange.
+// Check the length with:
+//     len(mockedServerInterface.ConfirmMobileChangeCalls())
+func (mock *ServerInterfaceMock) ConfirmMobileChangeCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockConfirmMobileChange.RLock()
+	calls = mock.calls.ConfirmMobileChange
+	lockServerInterfaceMockConfirmMobileChange.RUnlock()
+	return calls
+}
+
+// ConfirmTotp calls ConfirmTotpFunc.
+func (mock *ServerInterfaceMock) ConfirmTotp(ctx echo.Context) error {
+	if mock.ConfirmTotpFunc == nil {
+		panic("ServerInterfaceMock.ConfirmTotpFunc: method is nil but ServerInterface.ConfirmTotp was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockConfirmTotp.Lock()
+	mock.calls.ConfirmTotp = append(mock.calls.ConfirmTotp, callInfo)
+	lockServerInterfaceMockConfirmTotp.Unlock()
+	return mock.ConfirmTotpFunc(ctx)
+}
+
+// ConfirmTotpCalls gets all the calls that were made to ConfirmTotp.
+// Check the length with:
+//     len(mockedServerInterface.ConfirmTotpCalls())
+func (mock *ServerInterfaceMock) ConfirmTotpCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockConfirmTotp.RLock()
+	calls = mock.calls.ConfirmTotp
+	lockServerInterfaceMockConfirmTotp.RUnlock()
+	return calls
+}
+
+// CreateApiKey calls CreateApiKeyFunc.
+func (mock *ServerInterfaceMock) CreateApiKey(ctx echo.Context) error {
+	if mock.CreateApiKeyFunc == nil {
+		panic("ServerInterfaceMock.CreateApiKeyFunc: method is nil but ServerInterface.CreateApiKey was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockCreateApiKey.Lock()
+	mock.calls.CreateApiKey = append(mock.calls.CreateApiKey, callInfo)
+	lockServerInterfaceMockCreateApiKey.Unlock()
+	return mock.CreateApiKeyFunc(ctx)
+}
+
+// CreateApiKeyCalls gets all the calls that were made to CreateApiKey.
+// Check the length with:
+//     len(mockedServerInterface.CreateApiKeyCalls())
+func (mock *ServerInterfaceMock) CreateApiKeyCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockCreateApiKey.RLock()
+	calls = mock.calls.CreateApiKey
+	lockServerInterfaceMockCreateApiKey.RUnlock()
+	return calls
+}
+
+// CreateExport calls CreateExportFunc.
+func (mock *ServerInterfaceMock) CreateExport(ctx echo.Context) error {
+	if mock.CreateExportFunc == nil {
+		panic("ServerInterfaceMock.CreateExportFunc: method is nil but ServerInterface.CreateExport was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockCreateExport.Lock()
+	mock.calls.CreateExport = append(mock.calls.CreateExport, callInfo)
+	lockServerInterfaceMockCreateExport.Unlock()
+	return mock.CreateExportFunc(ctx)
+}
+
+// CreateExportCalls gets all the calls that were made to CreateExport.
+// Check the length with:
+//     len(mockedServerInterface.CreateExportCalls())
+func (mock *ServerInterfaceMock) CreateExportCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockCreateExport.RLock()
+	calls = mock.calls.CreateExport
+	lockServerInterfaceMockCreateExport.RUnlock()
+	return calls
+}
+
+// CreateOauthClient calls CreateOauthClientFunc.
+func (mock *ServerInterfaceMock) CreateOauthClient(ctx echo.Context) error {
+	if mock.CreateOauthClientFunc == nil {
+		panic("ServerInterfaceMock.CreateOauthClientFunc: method is nil but ServerInterface.CreateOauthClient was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockCreateOauthClient.Lock()
+	mock.calls.CreateOauthClient = append(mock.calls.CreateOauthClient, callInfo)
+	lockServerInterfaceMockCreateOauthClient.Unlock()
+	return mock.CreateOauthClientFunc(ctx)
+}
+
+// CreateOauthClientCalls gets all the calls that were made to CreateOauthClient.
+// Check the length with:
+//     len(mockedServerInterface.CreateOauthClientCalls())
+func (mock *ServerInterfaceMock) CreateOauthClientCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockCreateOauthClient.RLock()
+	calls = mock.calls.CreateOauthClient
+	lockServerInterfaceMockCreateOauthClient.RUnlock()
+	return calls
+}
+
+// DeleteApiKey calls DeleteApiKeyFunc.
+func (mock *ServerInterfaceMock) DeleteApiKey(ctx echo.Context, id int) error {
+	if mock.DeleteApiKeyFunc == nil {
+		panic("ServerInterfaceMock.DeleteApiKeyFunc: method is nil but ServerInterface.DeleteApiKey was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+		Id  int
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockServerInterfaceMockDeleteApiKey.Lock()
+	mock.calls.DeleteApiKey = append(mock.calls.DeleteApiKey, callInfo)
+	lockServerInterfaceMockDeleteApiKey.Unlock()
+	return mock.DeleteApiKeyFunc(ctx, id)
+}
+
+// DeleteApiKeyCalls gets all the calls that were made to DeleteApiKey.
+// Check the length with:
+//     len(mockedServerInterface.DeleteApiKeyCalls())
+func (mock *ServerInterfaceMock) DeleteApiKeyCalls() []struct {
+	Ctx echo.Context
+	Id  int
+} {
+	var calls []struct {
+		Ctx echo.Context
+		Id  int
+	}
+	lockServerInterfaceMockDeleteApiKey.RLock()
+	calls = mock.calls.DeleteApiKey
+	lockServerInterfaceMockDeleteApiKey.RUnlock()
+	return calls
+}
+
+// DeleteAvatar calls DeleteAvatarFunc.
+func (mock *ServerInterfaceMock) DeleteAvatar(ctx echo.Context) error {
+	if mock.DeleteAvatarFunc == nil {
+		panic("ServerInterfaceMock.DeleteAvatarFunc: method is nil but ServerInterface.DeleteAvatar was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockDeleteAvatar.Lock()
+	mock.calls.DeleteAvatar = append(mock.calls.DeleteAvatar, callInfo)
+	lockServerInterfaceMockDeleteAvatar.Unlock()
+	return mock.DeleteAvatarFunc(ctx)
+}
+
+// DeleteAvatarCalls gets all the calls that were made to DeleteAvatar.
+// Check the length with:
+//     len(mockedServerInterface.DeleteAvatarCalls())
+func (mock *ServerInterfaceMock) DeleteAvatarCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockDeleteAvatar.RLock()
+	calls = mock.calls.DeleteAvatar
+	lockServerInterfaceMockDeleteAvatar.RUnlock()
+	return calls
+}
+
+// DeleteMe calls DeleteMeFunc.
+func (mock *ServerInterfaceMock) DeleteMe(ctx echo.Context) error {
+	if mock.DeleteMeFunc == nil {
+		panic("ServerInterfaceMock.DeleteMeFunc: method is nil but ServerInterface.DeleteMe was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockDeleteMe.Lock()
+	mock.calls.DeleteMe = append(mock.calls.DeleteMe, callInfo)
+	lockServerInterfaceMockDeleteMe.Unlock()
+	return mock.DeleteMeFunc(ctx)
+}
+
+// DeleteMeCalls gets all the calls that were made to DeleteMe.
+// Check the length with:
+//     len(mockedServerInterface.DeleteMeCalls())
+func (mock *ServerInterfaceMock) DeleteMeCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockDeleteMe.RLock()
+	calls = mock.calls.DeleteMe
+	lockServerInterfaceMockDeleteMe.RUnlock()
+	return calls
+}
+
+// DeleteOauthClient calls DeleteOauthClientFunc.
+func (mock *ServerInterfaceMock) DeleteOauthClient(ctx echo.Context, clientId string) error {
+	if mock.DeleteOauthClientFunc == nil {
+		panic("ServerInterfaceMock.DeleteOauthClientFunc: method is nil but ServerInterface.DeleteOauthClient was just called")
+	}
+	callInfo := struct {
+		Ctx      echo.Context
+		ClientId string
+	}{
+		Ctx:      ctx,
+		ClientId: clientId,
+	}
+	lockServerInterfaceMockDeleteOauthClient.Lock()
+	mock.calls.DeleteOauthClient = append(mock.calls.DeleteOauthClient, callInfo)
+	lockServerInterfaceMockDeleteOauthClient.Unlock()
+	return mock.DeleteOauthClientFunc(ctx, clientId)
+}
+
+// DeleteOauthClientCalls gets all the calls that were made to DeleteOauthClient.
+// Check the length with:
+//     len(mockedServerInterface.DeleteOauthClientCalls())
+func (mock *ServerInterfaceMock) DeleteOauthClientCalls() []struct {
+	Ctx      echo.Context
+	ClientId string
+} {
+	var calls []struct {
+		Ctx      echo.Context
+		ClientId string
+	}
+	lockServerInterfaceMockDeleteOauthClient.RLock()
+	calls = mock.calls.DeleteOauthClient
+	lockServerInterfaceMockDeleteOauthClient.RUnlock()
+	return calls
+}
+
+// DeleteSession calls DeleteSessionFunc.
+func (mock *ServerInterfaceMock) DeleteSession(ctx echo.Context, id string) error {
+	if mock.DeleteSessionFunc == nil {
+		panic("ServerInterfaceMock.DeleteSessionFunc: method is nil but ServerInterface.DeleteSession was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+		Id  string
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockServerInterfaceMockDeleteSession.Lock()
+	mock.calls.DeleteSession = append(mock.calls.DeleteSession, callInfo)
+	lockServerInterfaceMockDeleteSession.Unlock()
+	return mock.DeleteSessionFunc(ctx, id)
+}
+
+// DeleteSessionCalls gets all the calls that were made to DeleteSession.
+// Check the length with:
+//     len(mockedServerInterface.DeleteSessionCalls())
+func (mock *ServerInterfaceMock) DeleteSessionCalls() []struct {
+	Ctx echo.Context
+	Id  string
+} {
+	var calls []struct {
+		Ctx echo.Context
+		Id  string
+	}
+	lockServerInterfaceMockDeleteSession.RLock()
+	calls = mock.calls.DeleteSession
+	lockServerInterfaceMockDeleteSession.RUnlock()
+	return calls
+}
+
+// DisableTotp calls DisableTotpFunc.
+func (mock *ServerInterfaceMock) DisableTotp(ctx echo.Context) error {
+	if mock.DisableTotpFunc == nil {
+		panic("ServerInterfaceMock.DisableTotpFunc: method is nil but ServerInterface.DisableTotp was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockDisableTotp.Lock()
+	mock.calls.DisableTotp = append(mock.calls.DisableTotp, callInfo)
+	lockServerInterfaceMockDisableTotp.Unlock()
+	return mock.DisableTotpFunc(ctx)
+}
+
+// DisableTotpCalls gets all the calls that were made to DisableTotp.
+// Check the length with:
+//     len(mockedServerInterface.DisableTotpCalls())
+func (mock *ServerInterfaceMock) DisableTotpCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockDisableTotp.RLock()
+	calls = mock.calls.DisableTotp
+	lockServerInterfaceMockDisableTotp.RUnlock()
+	return calls
+}
+
+// DownloadExport calls DownloadExportFunc.
+func (mock *ServerInterfaceMock) DownloadExport(ctx echo.Context, id string) error {
+	if mock.DownloadExportFunc == nil {
+		panic("ServerInterfaceMock.DownloadExportFunc: method is nil but ServerInterface.DownloadExport was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+		Id  string
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockServerInterfaceMockDownloadExport.Lock()
+	mock.calls.DownloadExport = append(mock.calls.DownloadExport, callInfo)
+	lockServerInterfaceMockDownloadExport.Unlock()
+	return mock.DownloadExportFunc(ctx, id)
+}
+
+// DownloadExportCalls gets all the calls that were made to DownloadExport.
+// Check the length with:
+//     len(mockedServerInterface.DownloadExportCalls())
+func (mock *ServerInterfaceMock) DownloadExportCalls() []struct {
+	Ctx echo.Context
+	Id  string
+} {
+	var calls []struct {
+		Ctx echo.Context
+		Id  string
+	}
+	lockServerInterfaceMockDownloadExport.RLock()
+	calls = mock.calls.DownloadExport
+	lockServerInterfaceMockDownloadExport.RUnlock()
+	return calls
+}
+
+// EnrollTotp calls EnrollTotpFunc.
+func (mock *ServerInterfaceMock) EnrollTotp(ctx echo.Context) error {
+	if mock.EnrollTotpFunc == nil {
+		panic("ServerInterfaceMock.EnrollTotpFunc: method is nil but ServerInterface.EnrollTotp was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockEnrollTotp.Lock()
+	mock.calls.EnrollTotp = append(mock.calls.EnrollTotp, callInfo)
+	lockServerInterfaceMockEnrollTotp.Unlock()
+	return mock.EnrollTotpFunc(ctx)
+}
+
+// EnrollTotpCalls gets all the calls that were made to EnrollTotp.
+// Check the length with:
+//     len(mockedServerInterface.EnrollTotpCalls())
+func (mock *ServerInterfaceMock) EnrollTotpCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockEnrollTotp.RLock()
+	calls = mock.calls.EnrollTotp
+	lockServerInterfaceMockEnrollTotp.RUnlock()
+	return calls
+}
+
+// EraseUser calls EraseUserFunc.
+func (mock *ServerInterfaceMock) EraseUser(ctx echo.Context, id int) error {
+	if mock.EraseUserFunc == nil {
+		panic("ServerInterfaceMock.EraseUserFunc: method is nil but ServerInterface.EraseUser was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+		Id  int
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockServerInterfaceMockEraseUser.Lock()
+	mock.calls.EraseUser = append(mock.calls.EraseUser, callInfo)
+	lockServerInterfaceMockEraseUser.Unlock()
+	return mock.EraseUserFunc(ctx, id)
+}
+
+// EraseUserCalls gets all the calls that were made to EraseUser.
+// Check the length with:
+//     len(mockedServerInterface.EraseUserCalls())
+func (mock *ServerInterfaceMock) EraseUserCalls() []struct {
+	Ctx echo.Context
+	Id  int
+} {
+	var calls []struct {
+		Ctx echo.Context
+		Id  int
+	}
+	lockServerInterfaceMockEraseUser.RLock()
+	calls = mock.calls.EraseUser
+	lockServerInterfaceMockEraseUser.RUnlock()
+	return calls
+}
+
+// ForgotPassword calls ForgotPasswordFunc.
+func (mock *ServerInterfaceMock) ForgotPassword(ctx echo.Context) error {
+	if mock.ForgotPasswordFunc == nil {
+		panic("ServerInterfaceMock.ForgotPasswordFunc: method is nil but ServerInterface.ForgotPassword was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockForgotPassword.Lock()
+	mock.calls.ForgotPassword = append(mock.calls.ForgotPassword, callInfo)
+	lockServerInterfaceMockForgotPassword.Unlock()
+	return mock.ForgotPasswordFunc(ctx)
+}
+
+// ForgotPasswordCalls gets all the calls that were made to ForgotPassword.
+// Check the length with:
+//     len(mockedServerInterface.ForgotPasswordCalls())
+func (mock *ServerInterfaceMock) ForgotPasswordCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockForgotPassword.RLock()
+	calls = mock.calls.ForgotPassword
+	lockServerInterfaceMockForgotPassword.RUnlock()
+	return calls
+}
+
+// GetApiKey calls GetApiKeyFunc.
+func (mock *ServerInterfaceMock) GetApiKey(ctx echo.Context, id int) error {
+	if mock.GetApiKeyFunc == nil {
+		panic("ServerInterfaceMock.GetApiKeyFunc: method is nil but ServerInterface.GetApiKey was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+		Id  int
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockServerInterfaceMockGetApiKey.Lock()
+	mock.calls.GetApiKey = append(mock.calls.GetApiKey, callInfo)
+	lockServerInterfaceMockGetApiKey.Unlock()
+	return mock.GetApiKeyFunc(ctx, id)
+}
+
+// GetApiKeyCalls gets all the calls that were made to GetApiKey.
+// Check the length with:
+//     len(mockedServerInterface.GetApiKeyCalls())
+func (mock *ServerInterfaceMock) GetApiKeyCalls() []struct {
+	Ctx echo.Context
+	Id  int
+} {
+	var calls []struct {
+		Ctx echo.Context
+		Id  int
+	}
+	lockServerInterfaceMockGetApiKey.RLock()
+	calls = mock.calls.GetApiKey
+	lockServerInterfaceMockGetApiKey.RUnlock()
+	return calls
+}
+
+// GetExport calls GetExportFunc.
+func (mock *ServerInterfaceMock) GetExport(ctx echo.Context, id string) error {
+	if mock.GetExportFunc == nil {
+		panic("ServerInterfaceMock.GetExportFunc: method is nil but ServerInterface.GetExport was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+		Id  string
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockServerInterfaceMockGetExport.Lock()
+	mock.calls.GetExport = append(mock.calls.GetExport, callInfo)
+	lockServerInterfaceMockGetExport.Unlock()
+	return mock.GetExportFunc(ctx, id)
+}
+
+// GetExportCalls gets all the calls that were made to GetExport.
+// Check the length with:
+//     len(mockedServerInterface.GetExportCalls())
+func (mock *ServerInterfaceMock) GetExportCalls() []struct {
+	Ctx echo.Context
+	Id  string
+} {
+	var calls []struct {
+		Ctx echo.Context
+		Id  string
+	}
+	lockServerInterfaceMockGetExport.RLock()
+	calls = mock.calls.GetExport
+	lockServerInterfaceMockGetExport.RUnlock()
+	return calls
+}
+
+// GetMe calls GetMeFunc.
+func (mock *ServerInterfaceMock) GetMe(ctx echo.Context) error {
+	if mock.GetMeFunc == nil {
+		panic("ServerInterfaceMock.GetMeFunc: method is nil but ServerInterface.GetMe was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockGetMe.Lock()
+	mock.calls.GetMe = append(mock.calls.GetMe, callInfo)
+	lockServerInterfaceMockGetMe.Unlock()
+	return mock.GetMeFunc(ctx)
+}
+
+// GetMeCalls gets all the calls that were made to GetMe.
+// Check the length with:
+//     len(mockedServerInterface.GetMeCalls())
+func (mock *ServerInterfaceMock) GetMeCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockGetMe.RLock()
+	calls = mock.calls.GetMe
+	lockServerInterfaceMockGetMe.RUnlock()
+	return calls
+}
+
+// GetOauthConsent calls GetOauthConsentFunc.
+func (mock *ServerInterfaceMock) GetOauthConsent(ctx echo.Context, params GetOauthConsentParams) error {
+	if mock.GetOauthConsentFunc == nil {
+		panic("ServerInterfaceMock.GetOauthConsentFunc: method is nil but ServerInterface.GetOauthConsent was just called")
+	}
+	callInfo := struct {
+		Ctx    echo.Context
+		Params GetOauthConsentParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	lockServerInterfaceMockGetOauthConsent.Lock()
+	mock.calls.GetOauthConsent = append(mock.calls.GetOauthConsent, callInfo)
+	lockServerInterfaceMockGetOauthConsent.Unlock()
+	return mock.GetOauthConsentFunc(ctx, params)
+}
+
+// GetOauthConsentCalls gets all the calls that were made to GetOauthConsent.
+// Check the length with:
+//     len(mockedServerInterface.GetOauthConsentCalls())
+func (mock *ServerInterfaceMock) GetOauthConsentCalls() []struct {
+	Ctx    echo.Context
+	Params GetOauthConsentParams
+} {
+	var calls []struct {
+		Ctx    echo.Context
+		Params GetOauthConsentParams
+	}
+	lockServerInterfaceMockGetOauthConsent.RLock()
+	calls = mock.calls.GetOauthConsent
+	lockServerInterfaceMockGetOauthConsent.RUnlock()
+	return calls
+}
+
+// GetUserinfo calls GetUserinfoFunc.
+func (mock *ServerInterfaceMock) GetUserinfo(ctx echo.Context) error {
+	if mock.GetUserinfoFunc == nil {
+		panic("ServerInterfaceMock.GetUserinfoFunc: method is nil but ServerInterface.GetUserinfo was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockGetUserinfo.Lock()
+	mock.calls.GetUserinfo = append(mock.calls.GetUserinfo, callInfo)
+	lockServerInterfaceMockGetUserinfo.Unlock()
+	return mock.GetUserinfoFunc(ctx)
+}
+
+// GetUserinfoCalls gets all the calls that were made to GetUserinfo.
+// Check the length with:
+//     len(mockedServerInterface.GetUserinfoCalls())
+func (mock *ServerInterfaceMock) GetUserinfoCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockGetUserinfo.RLock()
+	calls = mock.calls.GetUserinfo
+	lockServerInterfaceMockGetUserinfo.RUnlock()
+	return calls
+}
+
+// GrantOauthConsent calls GrantOauthConsentFunc.
+func (mock *ServerInterfaceMock) GrantOauthConsent(ctx echo.Context) error {
+	if mock.GrantOauthConsentFunc == nil {
+		panic("ServerInterfaceMock.GrantOauthConsentFunc: method is nil but ServerInterface.GrantOauthConsent was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockGrantOauthConsent.Lock()
+	mock.calls.GrantOauthConsent = append(mock.calls.GrantOauthConsent, callInfo)
+	lockServerInterfaceMockGrantOauthConsent.Unlock()
+	return mock.GrantOauthConsentFunc(ctx)
+}
+
+// GrantOauthConsentCalls gets all the calls that were made to GrantOauthConsent.
+// Check the length with:
+//     len(mockedServerInterface.GrantOauthConsentCalls())
+func (mock *ServerInterfaceMock) GrantOauthConsentCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockGrantOauthConsent.RLock()
+	calls = mock.calls.GrantOauthConsent
+	lockServerInterfaceMockGrantOauthConsent.RUnlock()
+	return calls
+}
+
+// ImpersonateUser calls ImpersonateUserFunc.
+func (mock *ServerInterfaceMock) ImpersonateUser(ctx echo.Context, id int) error {
+	if mock.ImpersonateUserFunc == nil {
+		panic("ServerInterfaceMock.ImpersonateUserFunc: method is nil but ServerInterface.ImpersonateUser was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+		Id  int
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockServerInterfaceMockImpersonateUser.Lock()
+	mock.calls.ImpersonateUser = append(mock.calls.ImpersonateUser, callInfo)
+	lockServerInterfaceMockImpersonateUser.Unlock()
+	return mock.ImpersonateUserFunc(ctx, id)
+}
+
+// ImpersonateUserCalls gets all the calls that were made to ImpersonateUser.
+// Check the length with:
+//     len(mockedServerInterface.ImpersonateUserCalls())
+func (mock *ServerInterfaceMock) ImpersonateUserCalls() []struct {
+	Ctx echo.Context
+	Id  int
+} {
+	var calls []struct {
+		Ctx echo.Context
+		Id  int
+	}
+	lockServerInterfaceMockImpersonateUser.RLock()
+	calls = mock.calls.ImpersonateUser
+	lockServerInterfaceMockImpersonateUser.RUnlock()
+	return calls
+}
+
+// ListApiKeys calls ListApiKeysFunc.
+func (mock *ServerInterfaceMock) ListApiKeys(ctx echo.Context) error {
+	if mock.ListApiKeysFunc == nil {
+		panic("ServerInterfaceMock.ListApiKeysFunc: method is nil but ServerInterface.ListApiKeys was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockListApiKeys.Lock()
+	mock.calls.ListApiKeys = append(mock.calls.ListApiKeys, callInfo)
+	lockServerInterfaceMockListApiKeys.Unlock()
+	return mock.ListApiKeysFunc(ctx)
+}
+
+// ListApiKeysCalls gets all the calls that were made to ListApiKeys.
+// Check the length with:
+//     len(mockedServerInterface.ListApiKeysCalls())
+func (mock *ServerInterfaceMock) ListApiKeysCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockListApiKeys.RLock()
+	calls = mock.calls.ListApiKeys
+	lockServerInterfaceMockListApiKeys.RUnlock()
+	return calls
+}
+
+// ListOauthClients calls ListOauthClientsFunc.
+func (mock *ServerInterfaceMock) ListOauthClients(ctx echo.Context) error {
+	if mock.ListOauthClientsFunc == nil {
+		panic("ServerInterfaceMock.ListOauthClientsFunc: method is nil but ServerInterface.ListOauthClients was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockListOauthClients.Lock()
+	mock.calls.ListOauthClients = append(mock.calls.ListOauthClients, callInfo)
+	lockServerInterfaceMockListOauthClients.Unlock()
+	return mock.ListOauthClientsFunc(ctx)
+}
+
+// ListOauthClientsCalls gets all the calls that were made to ListOauthClients.
+// Check the length with:
+//     len(mockedServerInterface.ListOauthClientsCalls())
+func (mock *ServerInterfaceMock) ListOauthClientsCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockListOauthClients.RLock()
+	calls = mock.calls.ListOauthClients
+	lockServerInterfaceMockListOauthClients.RUnlock()
+	return calls
+}
+
+// ListOauthConsents calls ListOauthConsentsFunc.
+func (mock *ServerInterfaceMock) ListOauthConsents(ctx echo.Context) error {
+	if mock.ListOauthConsentsFunc == nil {
+		panic("ServerInterfaceMock.ListOauthConsentsFunc: method is nil but ServerInterface.ListOauthConsents was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockListOauthConsents.Lock()
+	mock.calls.ListOauthConsents = append(mock.calls.ListOauthConsents, callInfo)
+	lockServerInterfaceMockListOauthConsents.Unlock()
+	return mock.ListOauthConsentsFunc(ctx)
+}
+
+// ListOauthConsentsCalls gets all the calls that were made to ListOauthConsents.
+// Check the length with:
+//     len(mockedServerInterface.ListOauthConsentsCalls())
+func (mock *ServerInterfaceMock) ListOauthConsentsCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockListOauthConsents.RLock()
+	calls = mock.calls.ListOauthConsents
+	lockServerInterfaceMockListOauthConsents.RUnlock()
+	return calls
+}
+
+// ListSessions calls ListSessionsFunc.
+func (mock *ServerInterfaceMock) ListSessions(ctx echo.Context) error {
+	if mock.ListSessionsFunc == nil {
+		panic("ServerInterfaceMock.ListSessionsFunc: method is nil but ServerInterface.ListSessions was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockListSessions.Lock()
+	mock.calls.ListSessions = append(mock.calls.ListSessions, callInfo)
+	lockServerInterfaceMockListSessions.Unlock()
+	return mock.ListSessionsFunc(ctx)
+}
+
+// ListSessionsCalls gets all the calls that were made to ListSessions.
+// Check the length with:
+//     len(mockedServerInterface.ListSessionsCalls())
+func (mock *ServerInterfaceMock) ListSessionsCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockListSessions.RLock()
+	calls = mock.calls.ListSessions
+	lockServerInterfaceMockListSessions.RUnlock()
+	return calls
+}
+
+// ListUsers calls ListUsersFunc.
+func (mock *ServerInterfaceMock) ListUsers(ctx echo.Context, params ListUsersParams) error {
+	if mock.ListUsersFunc == nil {
+		panic("ServerInterfaceMock.ListUsersFunc: method is nil but ServerInterface.ListUsers was just called")
+	}
+	callInfo := struct {
+		Ctx    echo.Context
+		Params ListUsersParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	lockServerInterfaceMockListUsers.Lock()
+	mock.calls.ListUsers = append(mock.calls.ListUsers, callInfo)
+	lockServerInterfaceMockListUsers.Unlock()
+	return mock.ListUsersFunc(ctx, params)
+}
+
+// ListUsersCalls gets all the calls that were made to ListUsers.
+// Check the length with:
+//     len(mockedServerInterface.ListUsersCalls())
+func (mock *ServerInterfaceMock) ListUsersCalls() []struct {
+	Ctx    echo.Context
+	Params ListUsersParams
+} {
+	var calls []struct {
+		Ctx    echo.Context
+		Params ListUsersParams
+	}
+	lockServerInterfaceMockListUsers.RLock()
+	calls = mock.calls.ListUsers
+	lockServerInterfaceMockListUsers.RUnlock()
+	return calls
+}
+
+// LoginUser calls LoginUserFunc.
+func (mock *ServerInterfaceMock) LoginUser(ctx echo.Context) error {
+	if mock.LoginUserFunc == nil {
+		panic("ServerInterfaceMock.LoginUserFunc: method is nil but ServerInterface.LoginUser was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockLoginUser.Lock()
+	mock.calls.LoginUser = append(mock.calls.LoginUser, callInfo)
+	lockServerInterfaceMockLoginUser.Unlock()
+	return mock.LoginUserFunc(ctx)
+}
+
+// LoginUserCalls gets all the calls that were made to LoginUser.
+// Check the length with:
+//     len(mockedServerInterface.LoginUserCalls())
+func (mock *ServerInterfaceMock) LoginUserCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockLoginUser.RLock()
+	calls = mock.calls.LoginUser
+	lockServerInterfaceMockLoginUser.RUnlock()
+	return calls
+}
+
+// LoginUserMfa calls LoginUserMfaFunc.
+func (mock *ServerInterfaceMock) LoginUserMfa(ctx echo.Context) error {
+	if mock.LoginUserMfaFunc == nil {
+		panic("ServerInterfaceMock.LoginUserMfaFunc: method is nil but ServerInterface.LoginUserMfa was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockLoginUserMfa.Lock()
+	mock.calls.LoginUserMfa = append(mock.calls.LoginUserMfa, callInfo)
+	lockServerInterfaceMockLoginUserMfa.Unlock()
+	return mock.LoginUserMfaFunc(ctx)
+}
+
+// LoginUserMfaCalls gets all the calls that were made to LoginUserMfa.
+// Check the length with:
+//     len(mockedServerInterface.LoginUserMfaCalls())
+func (mock *ServerInterfaceMock) LoginUserMfaCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockLoginUserMfa.RLock()
+	calls = mock.calls.LoginUserMfa
+	lockServerInterfaceMockLoginUserMfa.RUnlock()
+	return calls
+}
+
+// LogoutAllSessions calls LogoutAllSessionsFunc.
+func (mock *ServerInterfaceMock) LogoutAllSessions(ctx echo.Context) error {
+	if mock.LogoutAllSessionsFunc == nil {
+		panic("ServerInterfaceMock.LogoutAllSessionsFunc: method is nil but ServerInterface.LogoutAllSessions was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockLogoutAllSessions.Lock()
+	mock.calls.LogoutAllSessions = append(mock.calls.LogoutAllSessions, callInfo)
+	lockServerInterfaceMockLogoutAllSessions.Unlock()
+	return mock.LogoutAllSessionsFunc(ctx)
+}
+
+// LogoutAllSessionsCalls gets all the calls that were made to LogoutAllSessions.
+// Check the length with:
+//     len(mockedServerInterface.LogoutAllSessionsCalls())
+func (mock *ServerInterfaceMock) LogoutAllSessionsCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockLogoutAllSessions.RLock()
+	calls = mock.calls.LogoutAllSessions
+	lockServerInterfaceMockLogoutAllSessions.RUnlock()
+	return calls
+}
+
+// LogoutUser calls LogoutUserFunc.
+func (mock *ServerInterfaceMock) LogoutUser(ctx echo.Context) error {
+	if mock.LogoutUserFunc == nil {
+		panic("ServerInterfaceMock.LogoutUserFunc: method is nil but ServerInterface.LogoutUser was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockLogoutUser.Lock()
+	mock.calls.LogoutUser = append(mock.calls.LogoutUser, callInfo)
+	lockServerInterfaceMockLogoutUser.Unlock()
+	return mock.LogoutUserFunc(ctx)
+}
+
+// LogoutUserCalls gets all the calls that were made to LogoutUser.
+// Check the length with:
+//     len(mockedServerInterface.LogoutUserCalls())
+func (mock *ServerInterfaceMock) LogoutUserCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockLogoutUser.RLock()
+	calls = mock.calls.LogoutUser
+	lockServerInterfaceMockLogoutUser.RUnlock()
+	return calls
+}
+
+// ReactivateUser calls ReactivateUserFunc.
+func (mock *ServerInterfaceMock) ReactivateUser(ctx echo.Context, id int) error {
+	if mock.ReactivateUserFunc == nil {
+		panic("ServerInterfaceMock.ReactivateUserFunc: method is nil but ServerInterface.ReactivateUser was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+		Id  int
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockServerInterfaceMockReactivateUser.Lock()
+	mock.calls.ReactivateUser = append(mock.calls.ReactivateUser, callInfo)
+	lockServerInterfaceMockReactivateUser.Unlock()
+	return mock.ReactivateUserFunc(ctx, id)
+}
+
+// ReactivateUserCalls gets all the calls that were made to ReactivateUser.
+// Check the length with:
+//     len(mockedServerInterface.ReactivateUserCalls())
+func (mock *ServerInterfaceMock) ReactivateUserCalls() []struct {
+	Ctx echo.Context
+	Id  int
+} {
+	var calls []struct {
+		Ctx echo.Context
+		Id  int
+	}
+	lockServerInterfaceMockReactivateUser.RLock()
+	calls = mock.calls.ReactivateUser
+	lockServerInterfaceMockReactivateUser.RUnlock()
+	return calls
+}
+
+// RedeemMagicLink calls RedeemMagicLinkFunc.
+func (mock *ServerInterfaceMock) RedeemMagicLink(ctx echo.Context) error {
+	if mock.RedeemMagicLinkFunc == nil {
+		panic("ServerInterfaceMock.RedeemMagicLinkFunc: method is nil but ServerInterface.RedeemMagicLink was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockRedeemMagicLink.Lock()
+	mock.calls.RedeemMagicLink = append(mock.calls.RedeemMagicLink, callInfo)
+	lockServerInterfaceMockRedeemMagicLink.Unlock()
+	return mock.RedeemMagicLinkFunc(ctx)
+}
 
-	// calls tracks calls to the methods.
-	calls struct {
-		// LoginUser holds details about calls to the LoginUser method.
-		LoginUser []struct {
-			// Ctx is the ctx argument value.
-			Ctx echo.Context
-		}
-		// RegisterUser holds details about calls to the RegisterUser method.
-		RegisterUser []struct {
-			// Ctx is the ctx argument value.
-			Ctx echo.Context
-		}
+// RedeemMagicLinkCalls gets all the calls that were made to RedeemMagicLink.
+// Check the length with:
+//     len(mockedServerInterface.RedeemMagicLinkCalls())
+func (mock *ServerInterfaceMock) RedeemMagicLinkCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
 	}
+	lockServerInterfaceMockRedeemMagicLink.RLock()
+	calls = mock.calls.RedeemMagicLink
+	lockServerInterfaceMockRedeemMagicLink.RUnlock()
+	return calls
 }
 
-// LoginUser calls LoginUserFunc.
-func (mock *ServerInterfaceMock) LoginUser(ctx echo.Context) error {
-	if mock.LoginUserFunc == nil {
-		panic("ServerInterfaceMock.LoginUserFunc: method is nil but ServerInterface.LoginUser was just called")
+// RefreshToken calls RefreshTokenFunc.
+func (mock *ServerInterfaceMock) RefreshToken(ctx echo.Context) error {
+	if mock.RefreshTokenFunc == nil {
+		panic("ServerInterfaceMock.RefreshTokenFunc: method is nil but ServerInterface.RefreshToken was just called")
 	}
 	callInfo := struct {
 		Ctx echo.Context
 	}{
 		Ctx: ctx,
 	}
-	lockServerInterfaceMockLoginUser.Lock()
-	mock.calls.LoginUser = append(mock.calls.LoginUser, callInfo)
-	lockServerInterfaceMockLoginUser.Unlock()
-	return mock.LoginUserFunc(ctx)
+	lockServerInterfaceMockRefreshToken.Lock()
+	mock.calls.RefreshToken = append(mock.calls.RefreshToken, callInfo)
+	lockServerInterfaceMockRefreshToken.Unlock()
+	return mock.RefreshTokenFunc(ctx)
 }
 
-// LoginUserCalls gets all the calls that were made to LoginUser.
+// RefreshTokenCalls gets all the calls that were made to RefreshToken.
 // Check the length with:
-//     len(mockedServerInterface.LoginUserCalls())
-func (mock *ServerInterfaceMock) LoginUserCalls() []struct {
+//     len(mockedServerInterface.RefreshTokenCalls())
+func (mock *ServerInterfaceMock) RefreshTokenCalls() []struct {
 	Ctx echo.Context
 } {
 	var calls []struct {
 		Ctx echo.Context
 	}
-	lockServerInterfaceMockLoginUser.RLock()
-	calls = mock.calls.LoginUser
-	lockServerInterfaceMockLoginUser.RUnlock()
+	lockServerInterfaceMockRefreshToken.RLock()
+	calls = mock.calls.RefreshToken
+	lockServerInterfaceMockRefreshToken.RUnlock()
+	return calls
+}
+
+// RegenerateRecoveryCodes calls RegenerateRecoveryCodesFunc.
+func (mock *ServerInterfaceMock) RegenerateRecoveryCodes(ctx echo.Context) error {
+	if mock.RegenerateRecoveryCodesFunc == nil {
+		panic("ServerInterfaceMock.RegenerateRecoveryCodesFunc: method is nil but ServerInterface.RegenerateRecoveryCodes was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockRegenerateRecoveryCodes.Lock()
+	mock.calls.RegenerateRecoveryCodes = append(mock.calls.RegenerateRecoveryCodes, callInfo)
+	lockServerInterfaceMockRegenerateRecoveryCodes.Unlock()
+	return mock.RegenerateRecoveryCodesFunc(ctx)
+}
+
+// RegenerateRecoveryCodesCalls gets all the calls that were made to RegenerateRecoveryCodes.
+// Check the length with:
+//     len(mockedServerInterface.RegenerateRecoveryCodesCalls())
+func (mock *ServerInterfaceMock) RegenerateRecoveryCodesCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockRegenerateRecoveryCodes.RLock()
+	calls = mock.calls.RegenerateRecoveryCodes
+	lockServerInterfaceMockRegenerateRecoveryCodes.RUnlock()
 	return calls
 }
 
@@ -118,3 +2029,399 @@ func (mock *ServerInterfaceMock) RegisterUserCalls() []struct {
 	lockServerInterfaceMockRegisterUser.RUnlock()
 	return calls
 }
+
+// RemoveUserRole calls RemoveUserRoleFunc.
+func (mock *ServerInterfaceMock) RemoveUserRole(ctx echo.Context, id int, role string) error {
+	if mock.RemoveUserRoleFunc == nil {
+		panic("ServerInterfaceMock.RemoveUserRoleFunc: method is nil but ServerInterface.RemoveUserRole was just called")
+	}
+	callInfo := struct {
+		Ctx  echo.Context
+		Id   int
+		Role string
+	}{
+		Ctx:  ctx,
+		Id:   id,
+		Role: role,
+	}
+	lockServerInterfaceMockRemoveUserRole.Lock()
+	mock.calls.RemoveUserRole = append(mock.calls.RemoveUserRole, callInfo)
+	lockServerInterfaceMockRemoveUserRole.Unlock()
+	return mock.RemoveUserRoleFunc(ctx, id, role)
+}
+
+// RemoveUserRoleCalls gets all the calls that were made to RemoveUserRole.
+// Check the length with:
+//     len(mockedServerInterface.RemoveUserRoleCalls())
+func (mock *ServerInterfaceMock) RemoveUserRoleCalls() []struct {
+	Ctx  echo.Context
+	Id   int
+	Role string
+} {
+	var calls []struct {
+		Ctx  echo.Context
+		Id   int
+		Role string
+	}
+	lockServerInterfaceMockRemoveUserRole.RLock()
+	calls = mock.calls.RemoveUserRole
+	lockServerInterfaceMockRemoveUserRole.RUnlock()
+	return calls
+}
+
+// ResendEmailVerification calls ResendEmailVerificationFunc.
+func (mock *ServerInterfaceMock) ResendEmailVerification(ctx echo.Context) error {
+	if mock.ResendEmailVerificationFunc == nil {
+		panic("ServerInterfaceMock.ResendEmailVerificationFunc: method is nil but ServerInterface.ResendEmailVerification was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockResendEmailVerification.Lock()
+	mock.calls.ResendEmailVerification = append(mock.calls.ResendEmailVerification, callInfo)
+	lockServerInterfaceMockResendEmailVerification.Unlock()
+	return mock.ResendEmailVerificationFunc(ctx)
+}
+
+// ResendEmailVerificationCalls gets all the calls that were made to ResendEmailVerification.
+// Check the length with:
+//     len(mockedServerInterface.ResendEmailVerificationCalls())
+func (mock *ServerInterfaceMock) ResendEmailVerificationCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockResendEmailVerification.RLock()
+	calls = mock.calls.ResendEmailVerification
+	lockServerInterfaceMockResendEmailVerification.RUnlock()
+	return calls
+}
+
+// ResetPassword calls ResetPasswordFunc.
+func (mock *ServerInterfaceMock) ResetPassword(ctx echo.Context) error {
+	if mock.ResetPasswordFunc == nil {
+		panic("ServerInterfaceMock.ResetPasswordFunc: method is nil but ServerInterface.ResetPassword was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockResetPassword.Lock()
+	mock.calls.ResetPassword = append(mock.calls.ResetPassword, callInfo)
+	lockServerInterfaceMockResetPassword.Unlock()
+	return mock.ResetPasswordFunc(ctx)
+}
+
+// ResetPasswordCalls gets all the calls that were made to ResetPassword.
+// Check the length with:
+//     len(mockedServerInterface.ResetPasswordCalls())
+func (mock *ServerInterfaceMock) ResetPasswordCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockResetPassword.RLock()
+	calls = mock.calls.ResetPassword
+	lockServerInterfaceMockResetPassword.RUnlock()
+	return calls
+}
+
+// RestoreUser calls RestoreUserFunc.
+func (mock *ServerInterfaceMock) RestoreUser(ctx echo.Context, id int) error {
+	if mock.RestoreUserFunc == nil {
+		panic("ServerInterfaceMock.RestoreUserFunc: method is nil but ServerInterface.RestoreUser was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+		Id  int
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockServerInterfaceMockRestoreUser.Lock()
+	mock.calls.RestoreUser = append(mock.calls.RestoreUser, callInfo)
+	lockServerInterfaceMockRestoreUser.Unlock()
+	return mock.RestoreUserFunc(ctx, id)
+}
+
+// RestoreUserCalls gets all the calls that were made to RestoreUser.
+// Check the length with:
+//     len(mockedServerInterface.RestoreUserCalls())
+func (mock *ServerInterfaceMock) RestoreUserCalls() []struct {
+	Ctx echo.Context
+	Id  int
+} {
+	var calls []struct {
+		Ctx echo.Context
+		Id  int
+	}
+	lockServerInterfaceMockRestoreUser.RLock()
+	calls = mock.calls.RestoreUser
+	lockServerInterfaceMockRestoreUser.RUnlock()
+	return calls
+}
+
+// RevokeOauthConsent calls RevokeOauthConsentFunc.
+func (mock *ServerInterfaceMock) RevokeOauthConsent(ctx echo.Context, clientId string) error {
+	if mock.RevokeOauthConsentFunc == nil {
+		panic("ServerInterfaceMock.RevokeOauthConsentFunc: method is nil but ServerInterface.RevokeOauthConsent was just called")
+	}
+	callInfo := struct {
+		Ctx      echo.Context
+		ClientId string
+	}{
+		Ctx:      ctx,
+		ClientId: clientId,
+	}
+	lockServerInterfaceMockRevokeOauthConsent.Lock()
+	mock.calls.RevokeOauthConsent = append(mock.calls.RevokeOauthConsent, callInfo)
+	lockServerInterfaceMockRevokeOauthConsent.Unlock()
+	return mock.RevokeOauthConsentFunc(ctx, clientId)
+}
+
+// RevokeOauthConsentCalls gets all the calls that were made to RevokeOauthConsent.
+// Check the length with:
+//     len(mockedServerInterface.RevokeOauthConsentCalls())
+func (mock *ServerInterfaceMock) RevokeOauthConsentCalls() []struct {
+	Ctx      echo.Context
+	ClientId string
+} {
+	var calls []struct {
+		Ctx      echo.Context
+		ClientId string
+	}
+	lockServerInterfaceMockRevokeOauthConsent.RLock()
+	calls = mock.calls.RevokeOauthConsent
+	lockServerInterfaceMockRevokeOauthConsent.RUnlock()
+	return calls
+}
+
+// SendMagicLink calls SendMagicLinkFunc.
+func (mock *ServerInterfaceMock) SendMagicLink(ctx echo.Context) error {
+	if mock.SendMagicLinkFunc == nil {
+		panic("ServerInterfaceMock.SendMagicLinkFunc: method is nil but ServerInterface.SendMagicLink was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockSendMagicLink.Lock()
+	mock.calls.SendMagicLink = append(mock.calls.SendMagicLink, callInfo)
+	lockServerInterfaceMockSendMagicLink.Unlock()
+	return mock.SendMagicLinkFunc(ctx)
+}
+
+// SendMagicLinkCalls gets all the calls that were made to SendMagicLink.
+// Check the length with:
+//     len(mockedServerInterface.SendMagicLinkCalls())
+func (mock *ServerInterfaceMock) SendMagicLinkCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockSendMagicLink.RLock()
+	calls = mock.calls.SendMagicLink
+	lockServerInterfaceMockSendMagicLink.RUnlock()
+	return calls
+}
+
+// StopImpersonation calls StopImpersonationFunc.
+func (mock *ServerInterfaceMock) StopImpersonation(ctx echo.Context) error {
+	if mock.StopImpersonationFunc == nil {
+		panic("ServerInterfaceMock.StopImpersonationFunc: method is nil but ServerInterface.StopImpersonation was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockStopImpersonation.Lock()
+	mock.calls.StopImpersonation = append(mock.calls.StopImpersonation, callInfo)
+	lockServerInterfaceMockStopImpersonation.Unlock()
+	return mock.StopImpersonationFunc(ctx)
+}
+
+// StopImpersonationCalls gets all the calls that were made to StopImpersonation.
+// Check the length with:
+//     len(mockedServerInterface.StopImpersonationCalls())
+func (mock *ServerInterfaceMock) StopImpersonationCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockStopImpersonation.RLock()
+	calls = mock.calls.StopImpersonation
+	lockServerInterfaceMockStopImpersonation.RUnlock()
+	return calls
+}
+
+// SuspendUser calls SuspendUserFunc.
+func (mock *ServerInterfaceMock) SuspendUser(ctx echo.Context, id int) error {
+	if mock.SuspendUserFunc == nil {
+		panic("ServerInterfaceMock.SuspendUserFunc: method is nil but ServerInterface.SuspendUser was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+		Id  int
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockServerInterfaceMockSuspendUser.Lock()
+	mock.calls.SuspendUser = append(mock.calls.SuspendUser, callInfo)
+	lockServerInterfaceMockSuspendUser.Unlock()
+	return mock.SuspendUserFunc(ctx, id)
+}
+
+// SuspendUserCalls gets all the calls that were made to SuspendUser.
+// Check the length with:
+//     len(mockedServerInterface.SuspendUserCalls())
+func (mock *ServerInterfaceMock) SuspendUserCalls() []struct {
+	Ctx echo.Context
+	Id  int
+} {
+	var calls []struct {
+		Ctx echo.Context
+		Id  int
+	}
+	lockServerInterfaceMockSuspendUser.RLock()
+	calls = mock.calls.SuspendUser
+	lockServerInterfaceMockSuspendUser.RUnlock()
+	return calls
+}
+
+// UpdateApiKey calls UpdateApiKeyFunc.
+func (mock *ServerInterfaceMock) UpdateApiKey(ctx echo.Context, id int) error {
+	if mock.UpdateApiKeyFunc == nil {
+		panic("ServerInterfaceMock.UpdateApiKeyFunc: method is nil but ServerInterface.UpdateApiKey was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+		Id  int
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockServerInterfaceMockUpdateApiKey.Lock()
+	mock.calls.UpdateApiKey = append(mock.calls.UpdateApiKey, callInfo)
+	lockServerInterfaceMockUpdateApiKey.Unlock()
+	return mock.UpdateApiKeyFunc(ctx, id)
+}
+
+// UpdateApiKeyCalls gets all the calls that were made to UpdateApiKey.
+// Check the length with:
+//     len(mockedServerInterface.UpdateApiKeyCalls())
+func (mock *ServerInterfaceMock) UpdateApiKeyCalls() []struct {
+	Ctx echo.Context
+	Id  int
+} {
+	var calls []struct {
+		Ctx echo.Context
+		Id  int
+	}
+	lockServerInterfaceMockUpdateApiKey.RLock()
+	calls = mock.calls.UpdateApiKey
+	lockServerInterfaceMockUpdateApiKey.RUnlock()
+	return calls
+}
+
+// UpdateMe calls UpdateMeFunc.
+func (mock *ServerInterfaceMock) UpdateMe(ctx echo.Context) error {
+	if mock.UpdateMeFunc == nil {
+		panic("ServerInterfaceMock.UpdateMeFunc: method is nil but ServerInterface.UpdateMe was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockUpdateMe.Lock()
+	mock.calls.UpdateMe = append(mock.calls.UpdateMe, callInfo)
+	lockServerInterfaceMockUpdateMe.Unlock()
+	return mock.UpdateMeFunc(ctx)
+}
+
+// UpdateMeCalls gets all the calls that were made to UpdateMe.
+// Check the length with:
+//     len(mockedServerInterface.UpdateMeCalls())
+func (mock *ServerInterfaceMock) UpdateMeCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockUpdateMe.RLock()
+	calls = mock.calls.UpdateMe
+	lockServerInterfaceMockUpdateMe.RUnlock()
+	return calls
+}
+
+// UploadAvatar calls UploadAvatarFunc.
+func (mock *ServerInterfaceMock) UploadAvatar(ctx echo.Context) error {
+	if mock.UploadAvatarFunc == nil {
+		panic("ServerInterfaceMock.UploadAvatarFunc: method is nil but ServerInterface.UploadAvatar was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockUploadAvatar.Lock()
+	mock.calls.UploadAvatar = append(mock.calls.UploadAvatar, callInfo)
+	lockServerInterfaceMockUploadAvatar.Unlock()
+	return mock.UploadAvatarFunc(ctx)
+}
+
+// UploadAvatarCalls gets all the calls that were made to UploadAvatar.
+// Check the length with:
+//     len(mockedServerInterface.UploadAvatarCalls())
+func (mock *ServerInterfaceMock) UploadAvatarCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockUploadAvatar.RLock()
+	calls = mock.calls.UploadAvatar
+	lockServerInterfaceMockUploadAvatar.RUnlock()
+	return calls
+}
+
+// VerifyEmail calls VerifyEmailFunc.
+func (mock *ServerInterfaceMock) VerifyEmail(ctx echo.Context) error {
+	if mock.VerifyEmailFunc == nil {
+		panic("ServerInterfaceMock.VerifyEmailFunc: method is nil but ServerInterface.VerifyEmail was just called")
+	}
+	callInfo := struct {
+		Ctx echo.Context
+	}{
+		Ctx: ctx,
+	}
+	lockServerInterfaceMockVerifyEmail.Lock()
+	mock.calls.VerifyEmail = append(mock.calls.VerifyEmail, callInfo)
+	lockServerInterfaceMockVerifyEmail.Unlock()
+	return mock.VerifyEmailFunc(ctx)
+}
+
+// VerifyEmailCalls gets all the calls that were made to VerifyEmail.
+// Check the length with:
+//     len(mockedServerInterface.VerifyEmailCalls())
+func (mock *ServerInterfaceMock) VerifyEmailCalls() []struct {
+	Ctx echo.Context
+} {
+	var calls []struct {
+		Ctx echo.Context
+	}
+	lockServerInterfaceMockVerifyEmail.RLock()
+	calls = mock.calls.VerifyEmail
+	lockServerInterfaceMockVerifyEmail.RUnlock()
+	return calls
+}
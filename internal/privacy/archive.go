@@ -0,0 +1,142 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"go-api-template/internal/audit"
+	"go-api-template/internal/token"
+	"go-api-template/internal/user"
+	"time"
+)
+
+// archive is everything held about a user. Fields are spelled out rather
+// than taken from the models so that nothing internal, like password hashes,
+// ends up in an export by accident.
+type archive struct {
+	GeneratedAt time.Time    `json:"generated_at"`
+	Profile     profile      `json:"profile"`
+	Sessions    []session    `json:"sessions"`
+	AuditLog    []auditEntry `json:"audit_log"`
+}
+
+type profile struct {
+	ID              int        `json:"id"`
+	Email           string     `json:"email"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	Mobile          string     `json:"mobile"`
+	FirstName       string     `json:"first_name"`
+	LastName        string     `json:"last_name"`
+	ImageURL        string     `json:"image_url"`
+	Address         string     `json:"address"`
+	Active          bool       `json:"active"`
+	Roles           []string   `json:"roles"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type session struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+type auditEntry struct {
+	Action    string            `json:"action"`
+	ActorID   int               `json:"actor_id"`
+	UserID    int               `json:"user_id"`
+	IP        string            `json:"ip"`
+	Details   map[string]string `json:"details"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func newArchive(u *user.User, imageURL string, sessions []token.Session, entries []audit.Entry) *archive {
+	a := &archive{
+		GeneratedAt: time.Now(),
+		Profile: profile{
+			ID:              u.ID,
+			Email:           u.Email,
+			EmailVerifiedAt: u.EmailVerifiedAt,
+			Mobile:          u.Mobile,
+			FirstName:       u.FirstName,
+			LastName:        u.LastName,
+			ImageURL:        imageURL,
+			Address:         u.Address,
+			Active:          u.Active,
+			Roles:           u.RoleNames(),
+			CreatedAt:       u.CreatedAt,
+			UpdatedAt:       u.UpdatedAt,
+		},
+		Sessions: make([]session, 0, len(sessions)),
+		AuditLog: make([]auditEntry, 0, len(entries)),
+	}
+
+	for _, s := range sessions {
+		a.Sessions = append(a.Sessions, session{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+		})
+	}
+
+	for _, e := range entries {
+		a.AuditLog = append(a.AuditLog, auditEntry{
+			Action:    e.Action,
+			ActorID:   e.ActorID,
+			UserID:    e.UserID,
+			IP:        e.IP,
+			Details:   e.Details,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	return a
+}
+
+// encode writes the archive as a single JSON document or as a zip holding one
+// JSON file per kind of data
+func (a *archive) encode(format string) ([]byte, error) {
+	if format == FormatJSON {
+		return json.MarshalIndent(a, "", "  ")
+	}
+
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+
+	files := []struct {
+		name string
+		v    interface{}
+	}{
+		{"profile.json", a.Profile},
+		{"sessions.json", a.Sessions},
+		{"audit_log.json", a.AuditLog},
+	}
+	for _, f := range files {
+		fw, err := w.CreateHeader(&zip.FileHeader{
+			Name:     f.name,
+			Method:   zip.Deflate,
+			Modified: a.GeneratedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(f.v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := w.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
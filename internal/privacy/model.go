@@ -0,0 +1,50 @@
+package privacy
+
+import (
+	"context"
+	"time"
+)
+
+// Formats of data exports
+const (
+	FormatJSON = "json"
+	FormatZIP  = "zip"
+)
+
+// Statuses of data exports
+const (
+	StatusPending    = "pending"
+	StatusProcessing = "processing"
+	StatusReady      = "ready"
+	StatusFailed     = "failed"
+)
+
+// Export is a copy of the data held about a user, requested by them and
+// assembled in the background. The archive is kept until ExpiresAt.
+type Export struct {
+	tableName struct{} `pg:"data_exports,alias:data_exports"`
+
+	ID      string `pg:",pk"`
+	UserID  int    `pg:",notnull"`
+	Format  string `pg:",notnull"`
+	Status  string `pg:",notnull"`
+	Archive []byte
+
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time
+
+	CreatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *Export) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+
+	return c, nil
+}
+
+// Expired reports whether the archive can no longer be downloaded
+func (o *Export) Expired(now time.Time) bool {
+	return o.ExpiresAt != nil && !o.ExpiresAt.After(now)
+}
@@ -0,0 +1,194 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/rs/zerolog"
+)
+
+// Repository is data provider
+type Repository interface {
+	Create(ctx context.Context, e *Export) error
+	Find(ctx context.Context, userID int, id string) (*Export, error)
+	FindArchive(ctx context.Context, userID int, id string) (*Export, error)
+	HasPending(ctx context.Context, userID int) (bool, error)
+	Claim(ctx context.Context, staleBefore time.Time) (*Export, error)
+	Complete(ctx context.Context, id string, archive []byte, expiresAt time.Time) error
+	Fail(ctx context.Context, id string, expiresAt time.Time) error
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+	DeleteByUser(ctx context.Context, userID int) error
+}
+
+var (
+	errRepoExportNotFound = errors.New("export not found")
+)
+
+type repo struct {
+	logger zerolog.Logger
+	db     *pg.DB
+}
+
+func (r repo) Create(ctx context.Context, e *Export) error {
+	_, err := r.db.ModelContext(ctx, e).Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// Find returns an export of a user without its archive
+func (r repo) Find(ctx context.Context, userID int, id string) (*Export, error) {
+	e := &Export{}
+
+	err := r.db.ModelContext(ctx, e).
+		ExcludeColumn("archive").
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		First()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, errRepoExportNotFound
+		}
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// FindArchive returns an export of a user with its archive
+func (r repo) FindArchive(ctx context.Context, userID int, id string) (*Export, error) {
+	e := &Export{}
+
+	err := r.db.ModelContext(ctx, e).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		First()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, errRepoExportNotFound
+		}
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// HasPending reports whether an export of the user is still being assembled
+func (r repo) HasPending(ctx context.Context, userID int) (bool, error) {
+	exists, err := r.db.ModelContext(ctx, (*Export)(nil)).
+		Where("user_id = ?", userID).
+		WhereIn("status IN (?)", []string{StatusPending, StatusProcessing}).
+		Exists()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// Claim marks the oldest pending export as processing and returns it. An
+// export left processing since before staleBefore is taken over, whoever
+// had it is assumed gone. Concurrent callers never get the same export.
+func (r repo) Claim(ctx context.Context, staleBefore time.Time) (*Export, error) {
+	next := r.db.ModelContext(ctx, (*Export)(nil)).
+		Column("id").
+		Where("status = ?", StatusPending).
+		WhereOr("status = ? AND started_at < ?", StatusProcessing, staleBefore).
+		Order("created_at ASC").
+		Limit(1).
+		For("UPDATE SKIP LOCKED")
+
+	e := &Export{}
+	res, err := r.db.ModelContext(ctx, e).
+		Set("status = ?", StatusProcessing).
+		Set("started_at = ?", time.Now()).
+		Where("id = (?)", next).
+		Returning("id, user_id, format, status, started_at, created_at").
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	if res.RowsAffected() == 0 {
+		return nil, errRepoExportNotFound
+	}
+
+	return e, nil
+}
+
+func (r repo) Complete(ctx context.Context, id string, archive []byte, expiresAt time.Time) error {
+	_, err := r.db.ModelContext(ctx, (*Export)(nil)).
+		Set("status = ?", StatusReady).
+		Set("archive = ?", archive).
+		Set("completed_at = ?", time.Now()).
+		Set("expires_at = ?", expiresAt).
+		Where("id = ?", id).
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// Fail marks an export as failed, it is kept until expiresAt to tell the user
+func (r repo) Fail(ctx context.Context, id string, expiresAt time.Time) error {
+	_, err := r.db.ModelContext(ctx, (*Export)(nil)).
+		Set("status = ?", StatusFailed).
+		Set("completed_at = ?", time.Now()).
+		Set("expires_at = ?", expiresAt).
+		Where("id = ?", id).
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExpired removes the exports that can no longer be downloaded
+func (r repo) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	res, err := r.db.ModelContext(ctx, (*Export)(nil)).
+		Where("expires_at <= ?", now).
+		Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+func (r repo) DeleteByUser(ctx context.Context, userID int) error {
+	_, err := r.db.ModelContext(ctx, (*Export)(nil)).
+		Where("user_id = ?", userID).
+		Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// NewRepository creates a new repository
+func NewRepository(
+	logger zerolog.Logger,
+	db *pg.DB,
+) Repository {
+	return &repo{
+		logger: logger,
+		db:     db,
+	}
+}
@@ -0,0 +1,260 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"go-api-template/internal/audit"
+	"go-api-template/internal/token"
+	"go-api-template/internal/user"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Service is a service provider
+type Service interface {
+	RequestExport(ctx context.Context, userID int, format, ip string) (*Export, error)
+	GetExport(ctx context.Context, userID int, id string) (*Export, error)
+	DownloadExport(ctx context.Context, userID int, id, ip string) (*Export, error)
+	ProcessExports(ctx context.Context) (int, error)
+	Erase(ctx context.Context, actorID, userID int, reason, ip string) error
+}
+
+// Errors that can occur in the service
+var (
+	ErrInternalService = errors.New("internal service error")
+	ErrExportNotFound  = errors.New("export not found")
+	ErrExportPending   = errors.New("an export is already being prepared")
+	ErrExportNotReady  = errors.New("export is not ready")
+	ErrInvalidFormat   = errors.New("format must be json or zip")
+	ErrReasonRequired  = errors.New("a reason is required to erase a user")
+	ErrEraseSelf       = errors.New("can not erase yourself")
+)
+
+// staleAfter is how long an export can stay processing before another run
+// takes it over
+const staleAfter = 10 * time.Minute
+
+type service struct {
+	logger   zerolog.Logger
+	repo     Repository
+	users    user.Service
+	sessions token.Service
+	audit    audit.Service
+	ttl      time.Duration
+}
+
+// RequestExport queues the assembly of an archive of the data held about a
+// user. A user has at most one export in the works.
+func (s service) RequestExport(ctx context.Context, userID int, format, ip string) (*Export, error) {
+	if format != FormatJSON && format != FormatZIP {
+		return nil, ErrInvalidFormat
+	}
+
+	pending, err := s.repo.HasPending(ctx, userID)
+	if err != nil {
+		return nil, ErrInternalService
+	}
+	if pending {
+		return nil, ErrExportPending
+	}
+
+	e := &Export{
+		ID:     uuid.New().String(),
+		UserID: userID,
+		Format: format,
+		Status: StatusPending,
+	}
+
+	err = s.repo.Create(ctx, e)
+	if err != nil {
+		return nil, ErrInternalService
+	}
+
+	audit.RecordOrLog(ctx, s.audit, s.logger, &audit.Entry{
+		Action:  audit.ActionExportRequest,
+		ActorID: userID,
+		UserID:  userID,
+		IP:      ip,
+		Details: map[string]string{
+			"export_id": e.ID,
+			"format":    format,
+		},
+	})
+
+	return e, nil
+}
+
+func (s service) GetExport(ctx context.Context, userID int, id string) (*Export, error) {
+	e, err := s.repo.Find(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, errRepoExportNotFound) {
+			return nil, ErrExportNotFound
+		}
+
+		return nil, ErrInternalService
+	}
+
+	return e, nil
+}
+
+// DownloadExport returns a ready export with its archive
+func (s service) DownloadExport(ctx context.Context, userID int, id, ip string) (*Export, error) {
+	e, err := s.repo.FindArchive(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, errRepoExportNotFound) {
+			return nil, ErrExportNotFound
+		}
+
+		return nil, ErrInternalService
+	}
+
+	if e.Expired(time.Now()) {
+		return nil, ErrExportNotFound
+	}
+
+	if e.Status != StatusReady {
+		return nil, ErrExportNotReady
+	}
+
+	audit.RecordOrLog(ctx, s.audit, s.logger, &audit.Entry{
+		Action:  audit.ActionExportDownload,
+		ActorID: userID,
+		UserID:  userID,
+		IP:      ip,
+		Details: map[string]string{
+			"export_id": e.ID,
+		},
+	})
+
+	return e, nil
+}
+
+// ProcessExports assembles the pending exports and forgets the expired ones.
+// It returns how many exports it handled.
+func (s service) ProcessExports(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	_, err := s.repo.DeleteExpired(ctx, now)
+	if err != nil {
+		return 0, ErrInternalService
+	}
+
+	n := 0
+	for {
+		e, err := s.repo.Claim(ctx, now.Add(-staleAfter))
+		if err != nil {
+			if errors.Is(err, errRepoExportNotFound) {
+				return n, nil
+			}
+
+			return n, ErrInternalService
+		}
+
+		n++
+
+		data, err := s.assemble(ctx, e)
+		if err != nil {
+			s.logger.Error().Err(err).Str("export_id", e.ID).Int("user_id", e.UserID).Msg("assemble export")
+
+			err = s.repo.Fail(ctx, e.ID, time.Now().Add(s.ttl))
+			if err != nil {
+				return n, ErrInternalService
+			}
+
+			continue
+		}
+
+		err = s.repo.Complete(ctx, e.ID, data, time.Now().Add(s.ttl))
+		if err != nil {
+			return n, ErrInternalService
+		}
+	}
+}
+
+func (s service) assemble(ctx context.Context, e *Export) ([]byte, error) {
+	u, err := s.users.FindByID(ctx, e.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessions.ListSessions(ctx, e.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.audit.List(ctx, e.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newArchive(u, s.users.ImageURL(u), sessions, entries).encode(e.Format)
+}
+
+// Erase anonymizes the personal data of a user on behalf of an admin. The
+// user row and whatever references it stay, their sessions and exports go
+// and the audit entries about them lose addresses and details.
+func (s service) Erase(ctx context.Context, actorID, userID int, reason, ip string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrReasonRequired
+	}
+
+	if actorID == userID {
+		return ErrEraseSelf
+	}
+
+	err := s.users.Anonymize(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.sessions.RevokeAll(ctx, userID)
+	if err != nil {
+		return ErrInternalService
+	}
+
+	err = s.repo.DeleteByUser(ctx, userID)
+	if err != nil {
+		return ErrInternalService
+	}
+
+	err = s.audit.Anonymize(ctx, userID)
+	if err != nil {
+		return ErrInternalService
+	}
+
+	// recorded last, anonymizing would strip it otherwise
+	audit.RecordOrLog(ctx, s.audit, s.logger, &audit.Entry{
+		Action:  audit.ActionUserErase,
+		ActorID: actorID,
+		UserID:  userID,
+		IP:      ip,
+		Details: map[string]string{
+			"reason": reason,
+		},
+	})
+
+	return nil
+}
+
+// NewService creates a new service
+func NewService(
+	logger zerolog.Logger,
+	repo Repository,
+	users user.Service,
+	sessions token.Service,
+	audit audit.Service,
+	ttl time.Duration,
+) Service {
+	return &service{
+		logger:   logger,
+		repo:     repo,
+		users:    users,
+		sessions: sessions,
+		audit:    audit,
+		ttl:      ttl,
+	}
+}
@@ -0,0 +1,130 @@
+package privacy
+
+import (
+	"errors"
+	"fmt"
+	"go-api-template/internal/openapi"
+	"go-api-template/internal/user"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+// contentTypes of the archives by format
+var contentTypes = map[string]string{
+	FormatJSON: "application/json",
+	FormatZIP:  "application/zip",
+}
+
+// Transport handles transport for service
+type Transport struct {
+	logger        zerolog.Logger
+	srv           Service
+	currentUserID func(c echo.Context) int
+}
+
+// NewTransport creates a new transport
+func NewTransport(
+	logger zerolog.Logger,
+	srv Service,
+	currentUserID func(c echo.Context) int,
+) Transport {
+	return Transport{
+		logger:        logger,
+		srv:           srv,
+		currentUserID: currentUserID,
+	}
+}
+
+// CreateExport queues a data export of the current user
+func (h Transport) CreateExport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.ExportRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	e, err := h.srv.RequestExport(ctx, h.currentUserID(c), req.Format, c.RealIP())
+	if err != nil {
+		return h.error(err)
+	}
+
+	return c.JSON(http.StatusOK, export(e))
+}
+
+// GetExport returns the status of a data export of the current user
+func (h Transport) GetExport(c echo.Context, id string) error {
+	ctx := c.Request().Context()
+
+	e, err := h.srv.GetExport(ctx, h.currentUserID(c), id)
+	if err != nil {
+		return h.error(err)
+	}
+
+	return c.JSON(http.StatusOK, export(e))
+}
+
+// DownloadExport sends the archive of a ready data export
+func (h Transport) DownloadExport(c echo.Context, id string) error {
+	ctx := c.Request().Context()
+
+	e, err := h.srv.DownloadExport(ctx, h.currentUserID(c), id, c.RealIP())
+	if err != nil {
+		return h.error(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", "export-"+e.ID+"."+e.Format))
+
+	return c.Blob(http.StatusOK, contentTypes[e.Format], e.Archive)
+}
+
+// EraseUser anonymizes the personal data of a user
+func (h Transport) EraseUser(c echo.Context, id int) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.AdminActionRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	err = h.srv.Erase(ctx, h.currentUserID(c), id, req.Reason, c.RealIP())
+	if err != nil {
+		return h.error(err)
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "user erased",
+	})
+}
+
+func (h Transport) error(err error) error {
+	h.logger.Err(err).Msg("")
+
+	switch {
+	case errors.Is(err, ErrExportNotFound), errors.Is(err, user.ErrUserNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrInvalidFormat), errors.Is(err, ErrReasonRequired), errors.Is(err, ErrEraseSelf):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrExportPending), errors.Is(err, ErrExportNotReady):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+
+	return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+}
+
+func export(e *Export) openapi.Export {
+	return openapi.Export{
+		Id:          e.ID,
+		Format:      e.Format,
+		Status:      e.Status,
+		CreatedAt:   e.CreatedAt,
+		CompletedAt: e.CompletedAt,
+		ExpiresAt:   e.ExpiresAt,
+	}
+}
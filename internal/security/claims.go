@@ -1,6 +1,9 @@
 package security
 
 import (
+	"strings"
+	"time"
+
 	"github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo/v4"
 )
@@ -10,12 +13,95 @@ type JwtClaims struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 	UserID    int    `json:"user_id"`
+
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+
+	// SessionID is the session of the login the token was issued for
+	SessionID string `json:"sid,omitempty"`
+
+	// IssuedAtMicro is iat in microseconds. iat only has second precision,
+	// too coarse to tell a token issued right after every token of the user
+	// was revoked from the ones before.
+	IssuedAtMicro int64 `json:"iat_us,omitempty"`
+
+	// ClientID and Scope are set on tokens issued to OAuth clients. Tokens
+	// of the client credentials grant have no UserID.
+	ClientID string `json:"client_id,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+
+	// Act is set on impersonation tokens, the token acts for UserID on
+	// behalf of the actor (RFC 8693 4.1)
+	Act *Actor `json:"act,omitempty"`
+
+	// APIKeyID is set when the request was authenticated with an API key
+	// instead of a token. It is never part of a token.
+	APIKeyID int `json:"-"`
+
 	jwt.StandardClaims
 }
 
+// SetIssuedAt sets iat and its microseconds to t
+func (c *JwtClaims) SetIssuedAt(t time.Time) {
+	c.IssuedAt = t.Unix()
+	c.IssuedAtMicro = t.UnixNano() / int64(time.Microsecond)
+}
+
+// Issued returns when the token was issued, as precisely as the claims tell
+func (c *JwtClaims) Issued() time.Time {
+	if c.IssuedAtMicro != 0 {
+		return time.Unix(0, c.IssuedAtMicro*int64(time.Microsecond))
+	}
+
+	return time.Unix(c.IssuedAt, 0)
+}
+
+// Actor is the user an impersonation token was issued to
+type Actor struct {
+	UserID int `json:"user_id"`
+}
+
 // GetClaimFromEchoContext gets users claims form context
 func GetClaimFromEchoContext(c echo.Context) *JwtClaims {
 	claims := c.Get("user").(*JwtClaims)
 
 	return claims
 }
+
+// HasPermissions reports whether the claims grant every one of permissions
+func (c *JwtClaims) HasPermissions(permissions []string) bool {
+	granted := make(map[string]bool, len(c.Permissions))
+	for _, p := range c.Permissions {
+		granted[p] = true
+	}
+
+	for _, p := range permissions {
+		if !granted[p] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasScopes reports whether the claims of an OAuth token were granted every
+// one of scopes
+func (c *JwtClaims) HasScopes(scopes []string) bool {
+	granted := make(map[string]bool)
+	for _, s := range strings.Fields(c.Scope) {
+		granted[s] = true
+	}
+
+	for _, s := range scopes {
+		if !granted[s] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetUserIDFromEchoContext gets the id of the authenticated user from context
+func GetUserIDFromEchoContext(c echo.Context) int {
+	return GetClaimFromEchoContext(c).UserID
+}
@@ -0,0 +1,133 @@
+package security
+
+import (
+	"crypto/subtle"
+	"go-api-template/internal/config"
+	"go-api-template/internal/user"
+	"go-api-template/pkg/secret"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// refreshCookiePath limits the refresh cookie to the endpoints that take a
+// refresh token
+const refreshCookiePath = "/api/v1/user"
+
+// CookieAuth keeps the tokens of browser clients in HttpOnly cookies, out of
+// reach of scripts. Requests authenticated by cookie are guarded with a
+// double submit CSRF token: the app reads the CSRF cookie and echoes it in a
+// header, which a cross site request cannot do.
+type CookieAuth struct {
+	name       string
+	refresh    string
+	csrfCookie string
+	csrfHeader string
+	domain     string
+	secure     bool
+	refreshTTL time.Duration
+}
+
+// NewCookieAuth creates the cookie auth of the cookie source of lookup. It is
+// disabled when lookup has no cookie source.
+func NewCookieAuth(lookup *TokenLookup, cfg *config.Config) *CookieAuth {
+	return &CookieAuth{
+		name:       lookup.Cookie(),
+		refresh:    cfg.Auth.Cookie.Refresh,
+		csrfCookie: cfg.Auth.Cookie.CSRFCookie,
+		csrfHeader: cfg.Auth.Cookie.CSRFHeader,
+		domain:     cfg.Auth.Cookie.Domain,
+		secure:     cfg.Auth.Cookie.Secure,
+		refreshTTL: cfg.Auth.RefreshTokenTTL,
+	}
+}
+
+// Enabled reports whether tokens are kept in cookies
+func (a *CookieAuth) Enabled() bool {
+	return a.name != ""
+}
+
+// SetTokens sets the cookies of a token pair and a fresh CSRF token
+func (a *CookieAuth) SetTokens(c echo.Context, tokens *user.Tokens) error {
+	if !a.Enabled() {
+		return nil
+	}
+
+	csrf, err := secret.Token(secret.DefaultSize)
+	if err != nil {
+		return err
+	}
+
+	c.SetCookie(a.cookie(a.name, tokens.AccessToken, "/", time.Duration(tokens.ExpiresIn)*time.Second, true))
+	c.SetCookie(a.cookie(a.refresh, tokens.RefreshToken, refreshCookiePath, a.refreshTTL, true))
+	c.SetCookie(a.cookie(a.csrfCookie, csrf, "/", a.refreshTTL, false))
+
+	return nil
+}
+
+// Clear expires the cookies set by SetTokens
+func (a *CookieAuth) Clear(c echo.Context) {
+	if !a.Enabled() {
+		return
+	}
+
+	c.SetCookie(a.cookie(a.name, "", "/", -1, true))
+	c.SetCookie(a.cookie(a.refresh, "", refreshCookiePath, -1, true))
+	c.SetCookie(a.cookie(a.csrfCookie, "", "/", -1, false))
+}
+
+// RefreshToken returns the refresh token of the request's cookie, empty when
+// there is none
+func (a *CookieAuth) RefreshToken(c echo.Context) string {
+	if !a.Enabled() {
+		return ""
+	}
+
+	cookie, err := c.Cookie(a.refresh)
+	if err != nil {
+		return ""
+	}
+
+	return cookie.Value
+}
+
+// CheckCSRF checks that an unsafe request carries the CSRF cookie's value in
+// the CSRF header
+func (a *CookieAuth) CheckCSRF(c echo.Context) error {
+	switch c.Request().Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return nil
+	}
+
+	cookie, err := c.Cookie(a.csrfCookie)
+	if err != nil || cookie.Value == "" {
+		return ErrCSRFInvalid
+	}
+
+	header := c.Request().Header.Get(a.csrfHeader)
+	if subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) != 1 {
+		return ErrCSRFInvalid
+	}
+
+	return nil
+}
+
+// cookie returns a cookie of the auth, a negative maxAge deletes it
+func (a *CookieAuth) cookie(name, value, path string, maxAge time.Duration, httpOnly bool) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   a.domain,
+		Secure:   a.secure,
+		HttpOnly: httpOnly,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(maxAge / time.Second),
+	}
+	if maxAge < 0 {
+		cookie.MaxAge = -1
+	}
+
+	return cookie
+}
@@ -1,7 +1,9 @@
 package security
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
@@ -12,8 +14,16 @@ type (
 
 // Errors
 var (
-	ErrJWTMissing = echo.NewHTTPError(http.StatusBadRequest, "missing or malformed jwt")
-	ErrJWTInvalid = echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired jwt")
+	ErrJWTMissing    = echo.NewHTTPError(http.StatusBadRequest, "missing or malformed jwt")
+	ErrJWTInvalid    = echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired jwt")
+	ErrJWTRevoked    = echo.NewHTTPError(http.StatusUnauthorized, "revoked jwt")
+	ErrJWTScopes     = echo.NewHTTPError(http.StatusForbidden, "insufficient permissions")
+	ErrCSRFInvalid   = echo.NewHTTPError(http.StatusForbidden, "missing or invalid csrf token")
+	ErrImpersonating = echo.NewHTTPError(http.StatusForbidden, "not allowed while impersonating")
+	ErrUserInactive  = echo.NewHTTPError(http.StatusForbidden, "account is suspended")
+	ErrOAuthClient   = echo.NewHTTPError(http.StatusForbidden, "not allowed for oauth clients")
+
+	ErrAPIKeyInvalid = echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired api key")
 )
 
 // Defaults
@@ -69,3 +79,81 @@ func jwtFromCookie(name string) jwtExtractor {
 		return cookie.Value, nil
 	}
 }
+
+// Sources of a token lookup
+const (
+	SourceHeader = "header"
+	SourceQuery  = "query"
+	SourceParam  = "param"
+	SourceCookie = "cookie"
+)
+
+type tokenSource struct {
+	source  string
+	name    string
+	extract jwtExtractor
+}
+
+// TokenLookup finds the access token of a request in a list of sources
+type TokenLookup struct {
+	sources []tokenSource
+}
+
+// ParseTokenLookup parses a comma separated list of `<source>:<name>`, e.g.
+// "header:Authorization,cookie:session". Sources are tried in order.
+func ParseTokenLookup(lookup string) (*TokenLookup, error) {
+	l := &TokenLookup{}
+
+	for _, part := range strings.Split(lookup, ",") {
+		parts := strings.SplitN(strings.TrimSpace(part), ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid token lookup %q", part)
+		}
+
+		src := tokenSource{
+			source: parts[0],
+			name:   parts[1],
+		}
+		switch src.source {
+		case SourceHeader:
+			src.extract = jwtFromHeader(src.name, AuthScheme)
+		case SourceQuery:
+			src.extract = jwtFromQuery(src.name)
+		case SourceParam:
+			src.extract = jwtFromParam(src.name)
+		case SourceCookie:
+			src.extract = jwtFromCookie(src.name)
+		default:
+			return nil, fmt.Errorf("invalid token lookup source %q", src.source)
+		}
+
+		l.sources = append(l.sources, src)
+	}
+
+	return l, nil
+}
+
+// Cookie returns the name of the cookie tokens are looked up in, empty when
+// there is none
+func (l *TokenLookup) Cookie() string {
+	for _, src := range l.sources {
+		if src.source == SourceCookie {
+			return src.name
+		}
+	}
+
+	return ""
+}
+
+// extract returns the token of the first source the request carries one in
+// and the kind of that source
+func (l *TokenLookup) extract(c echo.Context) (string, string, error) {
+	for _, src := range l.sources {
+		token, err := src.extract(c)
+		if err == nil {
+			return token, src.source, nil
+		}
+	}
+
+	return "", "", ErrJWTMissing
+}
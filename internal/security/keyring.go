@@ -0,0 +1,266 @@
+package security
+
+import (
+	"errors"
+	"fmt"
+	"go-api-template/internal/config"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dgrijalva/jwt-go"
+	"gopkg.in/yaml.v2"
+)
+
+// Errors that can occur while managing a keyring
+var (
+	ErrKeyNotFound      = errors.New("key not found")
+	ErrKeyAlreadyExists = errors.New("key already exists")
+	ErrKeyActive        = errors.New("key is the active signing key")
+)
+
+// KeyConfig is a key of a keyring file. Asymmetric keys point to a PEM file,
+// HS256 keys carry their secret inline.
+type KeyConfig struct {
+	ID        string `yaml:"id"`
+	Algorithm string `yaml:"algorithm"`
+	File      string `yaml:"file,omitempty"`
+	Secret    string `yaml:"secret,omitempty"`
+}
+
+// KeyringFile is the on disk format of a keyring. The active key signs new
+// tokens, every other key only verifies tokens signed before it was replaced.
+type KeyringFile struct {
+	Active string      `yaml:"active"`
+	Keys   []KeyConfig `yaml:"keys"`
+}
+
+// ReadKeyringFile reads a keyring file
+func ReadKeyringFile(path string) (*KeyringFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read keyring %s: %v", path, err)
+	}
+
+	f := &KeyringFile{}
+	err = yaml.Unmarshal(data, f)
+	if err != nil {
+		return nil, fmt.Errorf("yaml decoder error : %v", err)
+	}
+
+	return f, nil
+}
+
+// Write atomically replaces the keyring file at path
+func (f *KeyringFile) Write(path string) error {
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".keyring-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Chmod(0600)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *KeyringFile) find(id string) int {
+	for i, k := range f.Keys {
+		if k.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Add adds a verify only key
+func (f *KeyringFile) Add(k KeyConfig) error {
+	if f.find(k.ID) >= 0 {
+		return ErrKeyAlreadyExists
+	}
+
+	f.Keys = append(f.Keys, k)
+
+	return nil
+}
+
+// Promote makes a key the active signing key, the previous one is kept for
+// verification
+func (f *KeyringFile) Promote(id string) error {
+	i := f.find(id)
+	if i < 0 {
+		return ErrKeyNotFound
+	}
+
+	key, err := f.Keys[i].load()
+	if err != nil {
+		return err
+	}
+
+	if !key.CanSign() {
+		return ErrNoSigningKey
+	}
+
+	f.Active = id
+
+	return nil
+}
+
+// Retire removes a key. Tokens it signed are no longer accepted.
+func (f *KeyringFile) Retire(id string) error {
+	i := f.find(id)
+	if i < 0 {
+		return ErrKeyNotFound
+	}
+
+	if f.Active == id {
+		return ErrKeyActive
+	}
+
+	f.Keys = append(f.Keys[:i], f.Keys[i+1:]...)
+
+	return nil
+}
+
+// KeySet loads every key of the keyring
+func (f *KeyringFile) KeySet() (*KeySet, error) {
+	var active *Key
+	others := make([]*Key, 0, len(f.Keys))
+
+	for _, kc := range f.Keys {
+		if kc.ID == "" {
+			return nil, errors.New("keyring keys need an id")
+		}
+
+		key, err := kc.load()
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %v", kc.ID, err)
+		}
+
+		if kc.ID == f.Active {
+			active = key
+			continue
+		}
+
+		others = append(others, key)
+	}
+
+	return NewKeySet(active, others...)
+}
+
+func (k KeyConfig) load() (*Key, error) {
+	if k.Algorithm == jwt.SigningMethodHS256.Alg() {
+		if k.Secret == "" {
+			return nil, errors.New("missing secret")
+		}
+
+		return NewHMACKey(k.ID, k.Secret), nil
+	}
+
+	return LoadKey(k.ID, k.Algorithm, k.File)
+}
+
+// Keyring is the KeySet tokens are signed and verified with. When backed by
+// a keyring file it can be reloaded while the server runs so keys can be
+// rotated without downtime.
+type Keyring struct {
+	file string
+
+	mu  sync.RWMutex
+	set *KeySet
+}
+
+// NewKeyring returns a keyring that never changes
+func NewKeyring(set *KeySet) *Keyring {
+	return &Keyring{
+		set: set,
+	}
+}
+
+// OpenKeyring opens the keyring configured in cfg. Without a keyring file
+// tokens are signed with the shared HS256 jwt key.
+func OpenKeyring(cfg *config.Config) (*Keyring, error) {
+	if cfg.Auth.Keyring == "" {
+		set, err := NewKeySet(NewHMACKey("", cfg.Server.JWTKey))
+		if err != nil {
+			return nil, err
+		}
+
+		return NewKeyring(set), nil
+	}
+
+	k := &Keyring{
+		file: cfg.Auth.Keyring,
+	}
+
+	err := k.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Reload reads the keyring file again. The current keys stay in use if it
+// cannot be loaded.
+func (k *Keyring) Reload() error {
+	if k.file == "" {
+		return nil
+	}
+
+	f, err := ReadKeyringFile(k.file)
+	if err != nil {
+		return err
+	}
+
+	set, err := f.KeySet()
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	k.set = set
+	k.mu.Unlock()
+
+	return nil
+}
+
+// KeySet returns the current keys
+func (k *Keyring) KeySet() *KeySet {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.set
+}
+
+// Sign signs claims with the active key
+func (k *Keyring) Sign(claims jwt.Claims) (string, error) {
+	return k.KeySet().Sign(claims)
+}
+
+// Keyfunc picks the verification key of a token
+func (k *Keyring) Keyfunc(t *jwt.Token) (interface{}, error) {
+	return k.KeySet().Keyfunc(t)
+}
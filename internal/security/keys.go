@@ -0,0 +1,287 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// Errors that can occur while loading keys
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported jwt signing algorithm")
+	ErrKeyMismatch          = errors.New("key does not match signing algorithm")
+	ErrNoSigningKey         = errors.New("no signing key")
+)
+
+// Key is a key tokens are signed with or verified against
+type Key struct {
+	ID     string
+	Method jwt.SigningMethod
+
+	// private signs tokens, it is nil for keys that can only verify
+	private interface{}
+	public  interface{}
+}
+
+// CanSign reports whether the key holds private material
+func (k *Key) CanSign() bool {
+	return k.private != nil
+}
+
+// IsSymmetric reports whether the key is a shared secret that must never be published
+func (k *Key) IsSymmetric() bool {
+	_, ok := k.Method.(*jwt.SigningMethodHMAC)
+	return ok
+}
+
+// NewHMACKey returns a HS256 key for a shared secret
+func NewHMACKey(id string, secret string) *Key {
+	return &Key{
+		ID:      id,
+		Method:  jwt.SigningMethodHS256,
+		private: []byte(secret),
+		public:  []byte(secret),
+	}
+}
+
+// LoadKey reads a PEM encoded key for algorithm from file. Private keys can
+// sign and verify, public keys only verify. When id is empty one is derived
+// from the public key.
+func LoadKey(id string, algorithm string, file string) (*Key, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read key %s: %v", file, err)
+	}
+
+	return ParseKey(id, algorithm, data)
+}
+
+// ParseKey parses a PEM encoded key for algorithm
+func ParseKey(id string, algorithm string, data []byte) (*Key, error) {
+	method := jwt.GetSigningMethod(algorithm)
+	if method == nil {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no pem block found")
+	}
+
+	if strings.Contains(block.Type, "PUBLIC KEY") {
+		public, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return newKey(id, method, nil, public)
+	}
+
+	private, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return newKey(id, method, private, private.Public())
+}
+
+// GenerateKey creates a new private key for algorithm, PEM encoded in PKCS8
+func GenerateKey(algorithm string) ([]byte, error) {
+	var private crypto.Signer
+	var err error
+
+	switch m := jwt.GetSigningMethod(algorithm).(type) {
+	case *jwt.SigningMethodRSA:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case *jwt.SigningMethodECDSA:
+		curves := map[int]elliptic.Curve{
+			256: elliptic.P256(),
+			384: elliptic.P384(),
+			521: elliptic.P521(),
+		}
+		private, err = ecdsa.GenerateKey(curves[m.CurveBits], rand.Reader)
+	case *signingMethodEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: der,
+	}), nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	return signer, nil
+}
+
+func newKey(id string, method jwt.SigningMethod, private crypto.Signer, public crypto.PublicKey) (*Key, error) {
+	if !keyMatchesMethod(method, public) {
+		return nil, ErrKeyMismatch
+	}
+
+	if id == "" {
+		var err error
+		id, err = thumbprint(public)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	k := &Key{
+		ID:     id,
+		Method: method,
+		public: public,
+	}
+	// a nil Signer must not end up as a non nil interface{}
+	if private != nil {
+		k.private = private
+	}
+
+	return k, nil
+}
+
+func keyMatchesMethod(method jwt.SigningMethod, public crypto.PublicKey) bool {
+	switch m := method.(type) {
+	case *jwt.SigningMethodRSA:
+		_, ok := public.(*rsa.PublicKey)
+		return ok
+	case *jwt.SigningMethodECDSA:
+		pub, ok := public.(*ecdsa.PublicKey)
+		return ok && pub.Curve.Params().BitSize == m.CurveBits
+	case *signingMethodEdDSA:
+		_, ok := public.(ed25519.PublicKey)
+		return ok
+	}
+
+	return false
+}
+
+// thumbprint derives a stable key id from the public key
+func thumbprint(public crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(der)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
+
+// KeySet holds the key new tokens are signed with and every key a token may
+// be verified against, looked up by the kid header
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+}
+
+// NewKeySet creates a key set that signs with signing and verifies with
+// signing and others
+func NewKeySet(signing *Key, others ...*Key) (*KeySet, error) {
+	if signing == nil || !signing.CanSign() {
+		return nil, ErrNoSigningKey
+	}
+
+	s := &KeySet{
+		signing: signing,
+		keys:    map[string]*Key{},
+	}
+
+	for _, k := range append([]*Key{signing}, others...) {
+		if _, ok := s.keys[k.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", k.ID)
+		}
+
+		s.keys[k.ID] = k
+	}
+
+	return s, nil
+}
+
+// Sign signs claims with the signing key
+func (s *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.signing.Method, claims)
+	if s.signing.ID != "" {
+		token.Header["kid"] = s.signing.ID
+	}
+
+	return token.SignedString(s.signing.private)
+}
+
+// Algorithm returns the algorithm new tokens are signed with
+func (s *KeySet) Algorithm() string {
+	return s.signing.Method.Alg()
+}
+
+// Keyfunc picks the verification key of a token by its kid header. Tokens
+// without a kid are checked against the key without an id, if there is one.
+func (s *KeySet) Keyfunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	k, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown jwt key id=%q", kid)
+	}
+
+	// Check the signing method
+	if t.Method.Alg() != k.Method.Alg() {
+		return nil, fmt.Errorf("unexpected jwt signing method=%v", t.Header["alg"])
+	}
+
+	return k.public, nil
+}
+
+// Keys returns every key of the set ordered by id
+func (s *KeySet) Keys() []*Key {
+	keys := make([]*Key, 0, len(s.keys))
+	for _, k := range s.keys {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys
+}
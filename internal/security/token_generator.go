@@ -5,30 +5,38 @@ import (
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 )
 
-// GenerateToken returns a function to  genrate a jwt token for a user
-func GenerateToken(jwtKey string) func(u *user.User) (string, error) {
-	return func(u *user.User) (string, error) {
+// GenerateToken returns a function to  genrate a jwt token for a session of a
+// user. It returns the token and its jti.
+func GenerateToken(keys *Keyring, ttl time.Duration) func(u *user.User, sessionID string) (string, string, error) {
+	return func(u *user.User, sessionID string) (string, string, error) {
+		now := time.Now()
+
 		// Set custom claims
 		claims := &JwtClaims{
 			FirstName: u.FirstName,
 			LastName:  u.LastName,
 			UserID:    u.ID,
+
+			Roles:       u.RoleNames(),
+			Permissions: u.Permissions(),
+			SessionID:   sessionID,
+
 			StandardClaims: jwt.StandardClaims{
-				ExpiresAt: time.Now().Add(time.Hour * 24 * 365).Unix(),
+				Id:        uuid.New().String(),
+				ExpiresAt: now.Add(ttl).Unix(),
 			},
 		}
-
-		// Create token with claims
-		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		claims.SetIssuedAt(now)
 
 		// Generate encoded token and send it as response.
-		t, err := token.SignedString([]byte(jwtKey))
+		t, err := keys.Sign(claims)
 		if err != nil {
-			return "", err
+			return "", "", err
 		}
 
-		return t, nil
+		return t, claims.Id, nil
 	}
 }
@@ -2,8 +2,10 @@ package security
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+	"errors"
 	"go-api-template/internal/user"
+	"net/http"
 
 	oapimiddleware "github.com/deepmap/oapi-codegen/pkg/middleware"
 	"github.com/dgrijalva/jwt-go"
@@ -12,8 +14,134 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// Names of the security schemes of the openapi spec
+const (
+	BearerAuthScheme = "bearerAuth"
+	APIKeyAuthScheme = "apiKeyAuth"
+)
+
+// SensitiveExtension marks operations of the openapi spec an impersonator
+// may not call, such as changing credentials
+const SensitiveExtension = "x-sensitive"
+
+// OAuthScopesExtension lists the OAuth scopes a token issued to a client needs
+// for an operation, on top of the scopes of its security requirement. Client
+// tokens are refused on operations without scopes of either kind, those are
+// for the first party apps only.
+const OAuthScopesExtension = "x-oauth-scopes"
+
+// APIKeyHeader is the header API keys are sent in
+const APIKeyHeader = "X-API-Key"
+
+// errNoCredentials is returned by a scheme the request carries no credentials
+// for. It is not an *echo.HTTPError so that the error of the scheme the
+// client did use is the one reported.
+var errNoCredentials = errors.New("no credentials for security scheme")
+
+// RevocationChecker reports whether a token was revoked before it expired
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, claims *JwtClaims) (bool, error)
+}
+
+// APIKeyChecker authenticates API keys. It returns the owner of a key and the
+// permissions the key grants.
+type APIKeyChecker interface {
+	CheckAPIKey(ctx context.Context, key string) (int, *user.User, []string, error)
+}
+
+// GrantChecker reports whether what a token issued to an OAuth client was
+// granted with still stands, the client and the consent of its user
+type GrantChecker interface {
+	IsGranted(ctx context.Context, claims *JwtClaims) (bool, error)
+}
+
+// Validator validates access tokens
+type Validator struct {
+	keys        *Keyring
+	getUserFunc func(ctx context.Context, id int) (*user.User, error)
+	revocations RevocationChecker
+	grants      GrantChecker
+}
+
+// NewValidator creates a new access token validator
+func NewValidator(keys *Keyring, getUserFunc func(ctx context.Context, id int) (*user.User, error), revocations RevocationChecker, grants GrantChecker) *Validator {
+	return &Validator{
+		keys:        keys,
+		getUserFunc: getUserFunc,
+		revocations: revocations,
+		grants:      grants,
+	}
+}
+
+// Validate returns the claims of an access token signed by one of the keys,
+// not expired, not revoked, still granted when issued to a client and issued
+// to a user that still exists and is active. Errors are *echo.HTTPError, a
+// token that is not valid gets a 401.
+func (v *Validator) Validate(ctx context.Context, token string) (*JwtClaims, error) {
+	claims := &JwtClaims{}
+
+	t, err := jwt.ParseWithClaims(token, claims, v.keys.Keyfunc)
+	if err == nil && t.Valid {
+		revoked, err := v.revocations.IsRevoked(ctx, claims)
+		if err != nil {
+			return nil, &echo.HTTPError{
+				Code:     http.StatusInternalServerError,
+				Message:  http.StatusText(http.StatusInternalServerError),
+				Internal: err,
+			}
+		}
+		if revoked {
+			return nil, ErrJWTRevoked
+		}
+
+		// deleting the client or revoking the consent ends its tokens
+		if claims.ClientID != "" {
+			granted, err := v.grants.IsGranted(ctx, claims)
+			if err != nil {
+				return nil, &echo.HTTPError{
+					Code:     http.StatusInternalServerError,
+					Message:  http.StatusText(http.StatusInternalServerError),
+					Internal: err,
+				}
+			}
+			if !granted {
+				return nil, ErrJWTRevoked
+			}
+		}
+
+		// client credentials tokens act for a client, not for a user
+		if claims.UserID == 0 && claims.ClientID != "" {
+			return claims, nil
+		}
+
+		var u *user.User
+		u, err = v.getUserFunc(ctx, claims.UserID)
+		if err == nil {
+			if !u.Active {
+				return nil, ErrUserInactive
+			}
+
+			return claims, nil
+		}
+	}
+
+	return nil, &echo.HTTPError{
+		Code:     ErrJWTInvalid.Code,
+		Message:  ErrJWTInvalid.Message,
+		Internal: err,
+	}
+}
+
+func init() {
+	// file parts of multipart bodies are decoded by their content type,
+	// avatars come as images
+	for _, contentType := range []string{"image/jpeg", "image/png", "image/gif"} {
+		openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.FileBodyDecoder)
+	}
+}
+
 // ValidationMiddleware returns a new ehco validator middleware for openapi
-func ValidationMiddleware(swagger *openapi3.Swagger, jwtKey string, getUserFunc func(ctx context.Context, id int) (*user.User, error)) echo.MiddlewareFunc {
+func ValidationMiddleware(swagger *openapi3.Swagger, validator *Validator, lookup *TokenLookup, cookies *CookieAuth, apiKeys APIKeyChecker) echo.MiddlewareFunc {
 	validatorOptions := &oapimiddleware.Options{
 		Options: openapi3filter.Options{
 			AuthenticationFunc: func(c context.Context, input *openapi3filter.AuthenticationInput) error {
@@ -22,41 +150,129 @@ func ValidationMiddleware(swagger *openapi3.Swagger, jwtKey string, getUserFunc
 					return echo.ErrBadRequest
 				}
 
-				extractor := jwtFromHeader(echo.HeaderAuthorization, AuthScheme)
-				auth, err := extractor(ec)
+				var (
+					claims *JwtClaims
+					err    error
+				)
+				switch input.SecuritySchemeName {
+				case APIKeyAuthScheme:
+					claims, err = authenticateAPIKey(c, ec, apiKeys)
+				default:
+					claims, err = authenticateBearer(c, ec, validator, lookup, cookies)
+				}
 				if err != nil {
 					return err
 				}
 
-				claims := &JwtClaims{}
+				// scopes listed for the operation's security requirement
+				// are the permissions it needs
+				if !claims.HasPermissions(input.Scopes) {
+					return ErrJWTScopes
+				}
 
-				token, err := jwt.ParseWithClaims(auth, claims, func(t *jwt.Token) (interface{}, error) {
-					// Check the signing method
-					if t.Method.Alg() != AlgorithmHS256 {
-						return nil, fmt.Errorf("unexpected jwt signing method=%v", t.Header["alg"])
-					}
+				op := input.RequestValidationInput.Route.Operation
 
-					return []byte(jwtKey), nil
-				})
-				if err == nil && token.Valid {
-					_, err := getUserFunc(c, claims.UserID)
-					if err == nil {
-						// claims.UserID = u.ID
-						// claims.UserID = u
-						// Store user information from token into context.
-						ec.Set(ContextKey, claims)
-						return nil
-					}
+				if claims.Act != nil && sensitive(op) {
+					return ErrImpersonating
 				}
 
-				return &echo.HTTPError{
-					Code:     ErrJWTInvalid.Code,
-					Message:  ErrJWTInvalid.Message,
-					Internal: err,
+				// a client only gets what the user granted it, never the
+				// whole account
+				if claims.ClientID != "" {
+					scopes := append(oauthScopes(op), input.Scopes...)
+					if len(scopes) == 0 || sensitive(op) || !claims.HasScopes(scopes) {
+						return ErrOAuthClient
+					}
 				}
+
+				// Store user information from token into context.
+				ec.Set(ContextKey, claims)
+				return nil
 			},
 		},
 	}
 
 	return oapimiddleware.OapiRequestValidatorWithOptions(swagger, validatorOptions)
 }
+
+func authenticateBearer(c context.Context, ec echo.Context, validator *Validator, lookup *TokenLookup, cookies *CookieAuth) (*JwtClaims, error) {
+	auth, source, err := lookup.extract(ec)
+	if err != nil {
+		if ec.Request().Header.Get(APIKeyHeader) != "" {
+			return nil, errNoCredentials
+		}
+
+		return nil, err
+	}
+
+	// browsers send cookies on their own, the request must prove it comes
+	// from the app
+	if source == SourceCookie {
+		err = cookies.CheckCSRF(ec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return validator.Validate(c, auth)
+}
+
+func authenticateAPIKey(c context.Context, ec echo.Context, apiKeys APIKeyChecker) (*JwtClaims, error) {
+	key := ec.Request().Header.Get(APIKeyHeader)
+	if key == "" {
+		return nil, errNoCredentials
+	}
+
+	id, u, permissions, err := apiKeys.CheckAPIKey(c, key)
+	if err != nil {
+		return nil, &echo.HTTPError{
+			Code:     ErrAPIKeyInvalid.Code,
+			Message:  ErrAPIKeyInvalid.Message,
+			Internal: err,
+		}
+	}
+
+	return &JwtClaims{
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		UserID:      u.ID,
+		Roles:       u.RoleNames(),
+		Permissions: permissions,
+		APIKeyID:    id,
+	}, nil
+}
+
+// oauthScopes returns the scopes listed in OAuthScopesExtension of an
+// operation
+func oauthScopes(op *openapi3.Operation) []string {
+	if op == nil {
+		return nil
+	}
+
+	raw, ok := op.Extensions[OAuthScopesExtension].(json.RawMessage)
+	if !ok {
+		return nil
+	}
+
+	var scopes []string
+	_ = json.Unmarshal(raw, &scopes)
+
+	return scopes
+}
+
+// sensitive reports whether an operation is marked with SensitiveExtension
+func sensitive(op *openapi3.Operation) bool {
+	if op == nil {
+		return false
+	}
+
+	raw, ok := op.Extensions[SensitiveExtension].(json.RawMessage)
+	if !ok {
+		return false
+	}
+
+	var marked bool
+	_ = json.Unmarshal(raw, &marked)
+
+	return marked
+}
@@ -0,0 +1,166 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// memoryRepo keeps attempts in memory. It is not shared between instances, so
+// it is only meant for development and as a fallback when the database is
+// unavailable.
+type memoryRepo struct {
+	mu        sync.Mutex
+	window    time.Duration
+	attempts  map[string]Attempt
+	lastSweep time.Time
+}
+
+func (r *memoryRepo) Find(ctx context.Context, key string) (*Attempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &a, nil
+}
+
+func (r *memoryRepo) Fail(ctx context.Context, key string, window time.Duration) (*Attempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	a := r.attempts[key]
+	if now.Sub(a.UpdatedAt) > window {
+		a.Failures = 0
+	}
+	a.Key = key
+	a.Failures++
+	a.UpdatedAt = now
+
+	r.attempts[key] = a
+	r.sweep()
+
+	return &a, nil
+}
+
+func (r *memoryRepo) Lock(ctx context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.attempts[key]
+	if !ok {
+		return nil
+	}
+
+	a.LockedUntil = &until
+	r.attempts[key] = a
+
+	return nil
+}
+
+func (r *memoryRepo) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+
+	return nil
+}
+
+// sweep drops attempts that are neither locked nor recent, at most once per
+// window. r.mu must be held.
+func (r *memoryRepo) sweep() {
+	now := time.Now()
+	if now.Sub(r.lastSweep) < r.window {
+		return
+	}
+
+	for key, a := range r.attempts {
+		if now.Sub(a.UpdatedAt) > r.window && a.lockedFor(now) == 0 {
+			delete(r.attempts, key)
+		}
+	}
+
+	r.lastSweep = now
+}
+
+// NewMemoryRepository creates a repository that keeps attempts in memory
+func NewMemoryRepository(window time.Duration) Repository {
+	return &memoryRepo{
+		window:    window,
+		attempts:  map[string]Attempt{},
+		lastSweep: time.Now(),
+	}
+}
+
+// fallbackRepo uses primary and switches to fallback for the calls primary
+// fails, so that logins keep being throttled while the database is down.
+type fallbackRepo struct {
+	logger   zerolog.Logger
+	primary  Repository
+	fallback Repository
+}
+
+func (r fallbackRepo) Find(ctx context.Context, key string) (*Attempt, error) {
+	mem, _ := r.fallback.Find(ctx, key)
+
+	a, err := r.primary.Find(ctx, key)
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("login attempts fallback to memory")
+		return mem, nil
+	}
+
+	// keep honouring locks taken while the database was down
+	now := time.Now()
+	if mem.lockedFor(now) > a.lockedFor(now) {
+		return mem, nil
+	}
+
+	return a, nil
+}
+
+func (r fallbackRepo) Fail(ctx context.Context, key string, window time.Duration) (*Attempt, error) {
+	a, err := r.primary.Fail(ctx, key, window)
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("login attempts fallback to memory")
+		return r.fallback.Fail(ctx, key, window)
+	}
+
+	return a, nil
+}
+
+func (r fallbackRepo) Lock(ctx context.Context, key string, until time.Time) error {
+	err := r.primary.Lock(ctx, key, until)
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("login attempts fallback to memory")
+	}
+
+	// the failure may have been counted in memory only
+	return r.fallback.Lock(ctx, key, until)
+}
+
+func (r fallbackRepo) Reset(ctx context.Context, key string) error {
+	err := r.primary.Reset(ctx, key)
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("login attempts fallback to memory")
+	}
+
+	return r.fallback.Reset(ctx, key)
+}
+
+// NewFallbackRepository creates a repository that falls back to memory when
+// primary fails
+func NewFallbackRepository(logger zerolog.Logger, primary Repository, window time.Duration) Repository {
+	return &fallbackRepo{
+		logger:   logger,
+		primary:  primary,
+		fallback: NewMemoryRepository(window),
+	}
+}
@@ -0,0 +1,25 @@
+package throttle
+
+import (
+	"time"
+)
+
+// Attempt counts the recent failed logins of a key, an account or an address
+type Attempt struct {
+	tableName struct{} `pg:"login_attempts,alias:login_attempts"`
+
+	Key         string `pg:",pk"`
+	Failures    int    `pg:",notnull,use_zero"`
+	LockedUntil *time.Time
+
+	UpdatedAt time.Time `pg:",notnull"`
+}
+
+// lockedFor returns how long the key stays locked
+func (a *Attempt) lockedFor(now time.Time) time.Duration {
+	if a == nil || a.LockedUntil == nil || !a.LockedUntil.After(now) {
+		return 0
+	}
+
+	return a.LockedUntil.Sub(now)
+}
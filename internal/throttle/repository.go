@@ -0,0 +1,99 @@
+package throttle
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/rs/zerolog"
+)
+
+// Repository is data provider
+type Repository interface {
+	Find(ctx context.Context, key string) (*Attempt, error)
+	Fail(ctx context.Context, key string, window time.Duration) (*Attempt, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+type repo struct {
+	logger zerolog.Logger
+	db     *pg.DB
+}
+
+// Find returns the attempts of key, nil when there are none
+func (r repo) Find(ctx context.Context, key string) (*Attempt, error) {
+	a := &Attempt{}
+
+	err := r.db.ModelContext(ctx, a).Where("key = ?", key).First()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Fail counts a failure in a single statement so that concurrent attempts are
+// all counted. Failures older than window are forgotten.
+func (r repo) Fail(ctx context.Context, key string, window time.Duration) (*Attempt, error) {
+	now := time.Now()
+	a := &Attempt{
+		Key:       key,
+		Failures:  1,
+		UpdatedAt: now,
+	}
+
+	_, err := r.db.ModelContext(ctx, a).
+		OnConflict("(key) DO UPDATE").
+		Set("failures = CASE WHEN login_attempts.updated_at < ? THEN 1 ELSE login_attempts.failures + 1 END", now.Add(-window)).
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("*").
+		Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (r repo) Lock(ctx context.Context, key string, until time.Time) error {
+	_, err := r.db.ModelContext(ctx, (*Attempt)(nil)).
+		Set("locked_until = ?", until).
+		Where("key = ?", key).
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+func (r repo) Reset(ctx context.Context, key string) error {
+	_, err := r.db.ModelContext(ctx, (*Attempt)(nil)).
+		Where("key = ?", key).
+		Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// NewRepository creates a new repository
+func NewRepository(
+	logger zerolog.Logger,
+	db *pg.DB,
+) Repository {
+	return &repo{
+		logger: logger,
+		db:     db,
+	}
+}
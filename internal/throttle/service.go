@@ -0,0 +1,278 @@
+package throttle
+
+import (
+	"context"
+	"errors"
+	"go-api-template/internal/config"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Service is a service provider
+type Service interface {
+	Check(ctx context.Context, email, ip string) error
+	Fail(ctx context.Context, email, ip string) error
+	Succeed(ctx context.Context, email string) error
+	CheckSecondFactor(ctx context.Context, userID int, ip string) error
+	FailSecondFactor(ctx context.Context, userID int, ip string) error
+	SucceedSecondFactor(ctx context.Context, userID int) error
+	Allow(ctx context.Context, key string, max int, window time.Duration) error
+}
+
+// Errors that can occur in the service
+var (
+	ErrInternalService = errors.New("internal service error")
+)
+
+// LockedError is returned while an account or address is locked out
+type LockedError struct {
+	After time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return "too many attempts, try again later"
+}
+
+// RetryAfter returns how long the client has to wait before trying again
+func (e *LockedError) RetryAfter() time.Duration {
+	return e.After
+}
+
+// limit is the lockout policy of a kind of key
+type limit struct {
+	prefix      string
+	maxFailures int
+}
+
+// attempt is the key of a limit an attempt counts against
+type attempt struct {
+	limit
+	value string
+}
+
+type service struct {
+	logger     zerolog.Logger
+	repo       Repository
+	account    limit
+	ip         limit
+	mfa        limit
+	window     time.Duration
+	lockout    time.Duration
+	maxLockout time.Duration
+}
+
+// Check returns a LockedError when the account or the address is locked
+func (s service) Check(ctx context.Context, email, ip string) error {
+	return s.check(ctx, s.loginAttempts(email, ip))
+}
+
+// Fail counts a failed login for the account and the address. Once a key has
+// more failures than allowed it gets locked, twice as long for every further
+// failure.
+func (s service) Fail(ctx context.Context, email, ip string) error {
+	return s.fail(ctx, s.loginAttempts(email, ip))
+}
+
+// Succeed forgets the failures of an account. Failures of the address are
+// kept, a single valid account must not unlock an address guessing others.
+// The second factor failures of the account are kept too, knowing the
+// password must not buy more guesses at the code.
+func (s service) Succeed(ctx context.Context, email string) error {
+	return s.reset(ctx, s.account.prefix+normalizeEmail(email))
+}
+
+// CheckSecondFactor returns a LockedError when the second factor of a user or
+// the address is locked
+func (s service) CheckSecondFactor(ctx context.Context, userID int, ip string) error {
+	return s.check(ctx, s.secondFactorAttempts(userID, ip))
+}
+
+// FailSecondFactor counts a wrong second factor code for the user and the
+// address, the failures of the user are kept apart from their password ones
+func (s service) FailSecondFactor(ctx context.Context, userID int, ip string) error {
+	return s.fail(ctx, s.secondFactorAttempts(userID, ip))
+}
+
+// SucceedSecondFactor forgets the second factor failures of a user
+func (s service) SucceedSecondFactor(ctx context.Context, userID int) error {
+	return s.reset(ctx, s.mfa.prefix+strconv.Itoa(userID))
+}
+
+func (s service) check(ctx context.Context, attempts []attempt) error {
+	now := time.Now()
+	var wait time.Duration
+
+	for _, l := range attempts {
+		if l.maxFailures <= 0 || l.value == "" {
+			continue
+		}
+
+		a, err := s.repo.Find(ctx, l.prefix+l.value)
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("")
+			return ErrInternalService
+		}
+
+		if d := a.lockedFor(now); d > wait {
+			wait = d
+		}
+	}
+
+	if wait > 0 {
+		return &LockedError{After: wait}
+	}
+
+	return nil
+}
+
+func (s service) fail(ctx context.Context, attempts []attempt) error {
+	now := time.Now()
+	var wait time.Duration
+
+	for _, l := range attempts {
+		if l.maxFailures <= 0 || l.value == "" {
+			continue
+		}
+
+		key := l.prefix + l.value
+
+		a, err := s.repo.Fail(ctx, key, s.window)
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("")
+			return ErrInternalService
+		}
+
+		if a.Failures < l.maxFailures {
+			continue
+		}
+
+		d := s.backoff(a.Failures - l.maxFailures)
+		err = s.repo.Lock(ctx, key, now.Add(d))
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("")
+			return ErrInternalService
+		}
+
+		s.logger.Warn().Str("key", key).Int("failures", a.Failures).Dur("lockout", d).Msg("login locked")
+
+		if d > wait {
+			wait = d
+		}
+	}
+
+	if wait > 0 {
+		return &LockedError{After: wait}
+	}
+
+	return nil
+}
+
+func (s service) reset(ctx context.Context, key string) error {
+	err := s.repo.Reset(ctx, key)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// Allow counts a request against key and returns a LockedError once more than
+// max requests were made without a pause of window. It rate limits actions
+// that are not logins, like sending emails.
+func (s service) Allow(ctx context.Context, key string, max int, window time.Duration) error {
+	if max <= 0 {
+		return nil
+	}
+
+	key = "rate:" + key
+
+	a, err := s.repo.Find(ctx, key)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	if d := a.lockedFor(time.Now()); d > 0 {
+		return &LockedError{After: d}
+	}
+
+	a, err = s.repo.Fail(ctx, key, window)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	if a.Failures <= max {
+		return nil
+	}
+
+	err = s.repo.Lock(ctx, key, time.Now().Add(window))
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	return &LockedError{After: window}
+}
+
+// backoff returns the lockout after n failures past the limit
+func (s service) backoff(n int) time.Duration {
+	d := float64(s.lockout) * math.Pow(2, float64(n))
+	if d > float64(s.maxLockout) {
+		return s.maxLockout
+	}
+
+	return time.Duration(d)
+}
+
+func (s service) loginAttempts(email, ip string) []attempt {
+	return []attempt{
+		{s.account, normalizeEmail(email)},
+		{s.ip, ip},
+	}
+}
+
+// secondFactorAttempts are keyed by user id, not email, so changing the
+// email does not forget them either
+func (s service) secondFactorAttempts(userID int, ip string) []attempt {
+	return []attempt{
+		{s.mfa, strconv.Itoa(userID)},
+		{s.ip, ip},
+	}
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NewService creates a new service
+func NewService(
+	logger zerolog.Logger,
+	repo Repository,
+	cfg *config.Config,
+) Service {
+	return &service{
+		logger: logger,
+		repo:   repo,
+		account: limit{
+			prefix:      "account:",
+			maxFailures: cfg.Throttle.MaxAccountFailures,
+		},
+		ip: limit{
+			prefix:      "ip:",
+			maxFailures: cfg.Throttle.MaxIPFailures,
+		},
+		mfa: limit{
+			prefix:      "mfa:",
+			maxFailures: cfg.Throttle.MaxAccountFailures,
+		},
+		window:     cfg.Throttle.Window,
+		lockout:    cfg.Throttle.Lockout,
+		maxLockout: cfg.Throttle.MaxLockout,
+	}
+}
@@ -0,0 +1,68 @@
+package throttle
+
+import (
+	"context"
+	"errors"
+	"go-api-template/internal/config"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestPasswordSuccessKeepsSecondFactorFailures(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := &config.Config{}
+	cfg.Throttle.MaxAccountFailures = 3
+	cfg.Throttle.Window = time.Minute
+	cfg.Throttle.Lockout = time.Minute
+	cfg.Throttle.MaxLockout = time.Hour
+
+	s := NewService(zerolog.Nop(), NewMemoryRepository(time.Minute), cfg)
+
+	// guesses are spread over logins, each proving the password again
+	for i := 0; i < cfg.Throttle.MaxAccountFailures-1; i++ {
+		err := s.CheckSecondFactor(ctx, 1, "")
+		if err != nil {
+			t.Fatalf("guess %d: %v", i, err)
+		}
+
+		err = s.FailSecondFactor(ctx, 1, "")
+		if err != nil {
+			t.Fatalf("guess %d: %v", i, err)
+		}
+
+		err = s.Succeed(ctx, "user@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := s.FailSecondFactor(ctx, 1, "")
+	var locked *LockedError
+	if !errors.As(err, &locked) {
+		t.Fatalf("last allowed failure: got %v, want a LockedError", err)
+	}
+
+	err = s.CheckSecondFactor(ctx, 1, "")
+	if !errors.As(err, &locked) {
+		t.Fatalf("check after lockout: got %v, want a LockedError", err)
+	}
+
+	// another user is not affected
+	err = s.CheckSecondFactor(ctx, 2, "")
+	if err != nil {
+		t.Fatalf("other user: %v", err)
+	}
+
+	err = s.SucceedSecondFactor(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.CheckSecondFactor(ctx, 1, "")
+	if err != nil {
+		t.Fatalf("check after success: %v", err)
+	}
+}
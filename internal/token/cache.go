@@ -0,0 +1,98 @@
+package token
+
+import (
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	userID  int
+	revoked bool
+	until   time.Time
+}
+
+// revocationCache keeps the result of revocation lookups in memory so that
+// validating a token does not hit the database on every request. A revoked
+// token stays revoked, so positive results are kept until the token expires,
+// negative results only for ttl since another instance may revoke the token.
+type revocationCache struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	entries   map[string]cacheEntry
+	lastSweep time.Time
+}
+
+func newRevocationCache(ttl time.Duration) *revocationCache {
+	return &revocationCache{
+		ttl:       ttl,
+		entries:   map[string]cacheEntry{},
+		lastSweep: time.Now(),
+	}
+}
+
+// get returns whether jti is revoked and whether the answer was cached
+func (c *revocationCache) get(jti string) (bool, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.entries[jti]
+	if !ok || time.Now().After(e.until) {
+		return false, false
+	}
+
+	return e.revoked, true
+}
+
+func (c *revocationCache) set(jti string, userID int, revoked bool, expiresAt time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	until := expiresAt
+	if !revoked {
+		until = time.Now().Add(c.ttl)
+		if until.After(expiresAt) {
+			until = expiresAt
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[jti] = cacheEntry{
+		userID:  userID,
+		revoked: revoked,
+		until:   until,
+	}
+
+	c.sweep()
+}
+
+// forgetUser drops the cached negative results of a user. Positive ones can
+// stay as revocation is never undone.
+func (c *revocationCache) forgetUser(userID int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for jti, e := range c.entries {
+		if e.userID == userID && !e.revoked {
+			delete(c.entries, jti)
+		}
+	}
+}
+
+// sweep drops expired entries, at most once per ttl. c.mu must be held.
+func (c *revocationCache) sweep() {
+	now := time.Now()
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+
+	for jti, e := range c.entries {
+		if now.After(e.until) {
+			delete(c.entries, jti)
+		}
+	}
+
+	c.lastSweep = now
+}
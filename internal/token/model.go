@@ -0,0 +1,80 @@
+package token
+
+import (
+	"context"
+	"time"
+)
+
+// RefreshToken is an opaque long lived token that can be exchanged for a new
+// access token. Only a hash of the token is stored.
+type RefreshToken struct {
+	tableName struct{} `pg:"refresh_tokens,alias:refresh_tokens"`
+
+	ID        int    `pg:",pk"`
+	UserID    int    `pg:",notnull"`
+	FamilyID  string `pg:",notnull"`
+	TokenHash string `pg:",unique,notnull"`
+
+	ExpiresAt time.Time `pg:",notnull"`
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+
+	CreatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *RefreshToken) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+
+	return c, nil
+}
+
+// Session is a login of a user on a device. The refresh tokens handed out for
+// the login form a family whose ID is the ID of the session, their access
+// tokens carry it in the sid claim.
+type Session struct {
+	tableName struct{} `pg:"sessions,alias:sessions"`
+
+	ID        string `pg:",pk"`
+	UserID    int    `pg:",notnull"`
+	JTI       string `pg:",notnull,use_zero"`
+	UserAgent string `pg:",notnull,use_zero"`
+	IP        string `pg:",notnull,use_zero"`
+
+	CreatedAt  time.Time `pg:",notnull"`
+	LastSeenAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *Session) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+	o.LastSeenAt = o.CreatedAt
+
+	return c, nil
+}
+
+// RevokedToken is an access token that was revoked before it expired
+type RevokedToken struct {
+	tableName struct{} `pg:"revoked_tokens,alias:revoked_tokens"`
+
+	JTI       string    `pg:",pk"`
+	UserID    int       `pg:",notnull"`
+	ExpiresAt time.Time `pg:",notnull"`
+
+	CreatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *RevokedToken) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+
+	return c, nil
+}
+
+// UserRevocation revokes every access token of a user issued before a point in time
+type UserRevocation struct {
+	tableName struct{} `pg:"user_revocations,alias:user_revocations"`
+
+	UserID        int       `pg:",pk"`
+	RevokedBefore time.Time `pg:",notnull"`
+}
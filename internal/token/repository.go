@@ -0,0 +1,261 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/rs/zerolog"
+)
+
+// Repository is data provider
+type Repository interface {
+	Create(ctx context.Context, t *RefreshToken) (*RefreshToken, error)
+	FindByHash(ctx context.Context, hash string) (*RefreshToken, error)
+	MarkUsed(ctx context.Context, id int) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeToken(ctx context.Context, t *RevokedToken) error
+	RevokeUser(ctx context.Context, userID int, before time.Time) error
+	IsRevoked(ctx context.Context, jti string, userID int, issuedAt time.Time) (bool, error)
+	CreateSession(ctx context.Context, sess *Session) error
+	TouchSession(ctx context.Context, id string, jti string) error
+	ListSessions(ctx context.Context, userID int) ([]Session, error)
+	DeleteSession(ctx context.Context, userID int, id string) (*Session, error)
+}
+
+var (
+	errRepoTokenNotFound    = errors.New("refresh token not found")
+	errRepoTokenAlreadyUsed = errors.New("refresh token already used")
+	errRepoSessionNotFound  = errors.New("session not found")
+)
+
+type repo struct {
+	logger zerolog.Logger
+	db     *pg.DB
+}
+
+func (r repo) Create(ctx context.Context, t *RefreshToken) (*RefreshToken, error) {
+	_, err := r.db.ModelContext(ctx, t).Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (r repo) FindByHash(ctx context.Context, hash string) (*RefreshToken, error) {
+	t := &RefreshToken{}
+
+	err := r.db.ModelContext(ctx, t).Where("token_hash = ?", hash).First()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, errRepoTokenNotFound
+		}
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// MarkUsed marks a token as used. Only one caller can ever succeed for a given
+// token, everyone else gets errRepoTokenAlreadyUsed.
+func (r repo) MarkUsed(ctx context.Context, id int) error {
+	res, err := r.db.ModelContext(ctx, (*RefreshToken)(nil)).
+		Set("used_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("used_at IS NULL").
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoTokenAlreadyUsed
+	}
+
+	return nil
+}
+
+// RevokeFamily revokes the refresh tokens of a family and ends its session
+func (r repo) RevokeFamily(ctx context.Context, familyID string) error {
+	err := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.ModelContext(ctx, (*Session)(nil)).
+			Where("id = ?", familyID).
+			Delete()
+		if err != nil {
+			return err
+		}
+
+		return revokeFamily(ctx, tx, familyID)
+	})
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+func revokeFamily(ctx context.Context, tx *pg.Tx, familyID string) error {
+	_, err := tx.ModelContext(ctx, (*RefreshToken)(nil)).
+		Set("revoked_at = ?", time.Now()).
+		Where("family_id = ?", familyID).
+		Where("revoked_at IS NULL").
+		Update()
+	return err
+}
+
+func (r repo) RevokeToken(ctx context.Context, t *RevokedToken) error {
+	_, err := r.db.ModelContext(ctx, t).OnConflict("DO NOTHING").Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// RevokeUser revokes every access token issued to a user before the given
+// time along with all of the user's refresh tokens and sessions
+func (r repo) RevokeUser(ctx context.Context, userID int, before time.Time) error {
+	err := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.ModelContext(ctx, &UserRevocation{
+			UserID:        userID,
+			RevokedBefore: before,
+		}).
+			OnConflict("(user_id) DO UPDATE").
+			Set("revoked_before = EXCLUDED.revoked_before").
+			Insert()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ModelContext(ctx, (*Session)(nil)).
+			Where("user_id = ?", userID).
+			Delete()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ModelContext(ctx, (*RefreshToken)(nil)).
+			Set("revoked_at = ?", before).
+			Where("user_id = ?", userID).
+			Where("revoked_at IS NULL").
+			Update()
+		return err
+	})
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// IsRevoked reports whether a token was revoked by its id, or along with
+// every token of its user issued before the cutoff
+func (r repo) IsRevoked(ctx context.Context, jti string, userID int, issuedAt time.Time) (bool, error) {
+	var revoked bool
+
+	_, err := r.db.QueryOneContext(ctx, pg.Scan(&revoked), `
+		SELECT EXISTS (SELECT 1 FROM "revoked_tokens" WHERE "jti" = ?)
+			OR EXISTS (SELECT 1 FROM "user_revocations" WHERE "user_id" = ? AND "revoked_before" > ?)
+	`, jti, userID, issuedAt)
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+func (r repo) CreateSession(ctx context.Context, sess *Session) error {
+	_, err := r.db.ModelContext(ctx, sess).Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// TouchSession records that a session was just used. A non empty jti is the
+// latest access token issued for it.
+func (r repo) TouchSession(ctx context.Context, id string, jti string) error {
+	q := r.db.ModelContext(ctx, (*Session)(nil)).
+		Set("last_seen_at = ?", time.Now()).
+		Where("id = ?", id)
+	if jti != "" {
+		q = q.Set("jti = ?", jti)
+	}
+
+	res, err := q.Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoSessionNotFound
+	}
+
+	return nil
+}
+
+func (r repo) ListSessions(ctx context.Context, userID int) ([]Session, error) {
+	sessions := []Session{}
+
+	err := r.db.ModelContext(ctx, &sessions).
+		Where("user_id = ?", userID).
+		Order("last_seen_at DESC").
+		Select()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession ends a session of a user and revokes its refresh tokens
+func (r repo) DeleteSession(ctx context.Context, userID int, id string) (*Session, error) {
+	sess := &Session{}
+
+	err := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		res, err := tx.ModelContext(ctx, sess).
+			Where("id = ?", id).
+			Where("user_id = ?", userID).
+			Returning("*").
+			Delete()
+		if err != nil {
+			return err
+		}
+
+		if res.RowsAffected() == 0 {
+			return errRepoSessionNotFound
+		}
+
+		return revokeFamily(ctx, tx, id)
+	})
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	return sess, nil
+}
+
+// NewRepository creates a new repository
+func NewRepository(
+	logger zerolog.Logger,
+	db *pg.DB,
+) Repository {
+	return &repo{
+		logger: logger,
+		db:     db,
+	}
+}
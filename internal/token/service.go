@@ -0,0 +1,328 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"go-api-template/internal/security"
+	"go-api-template/internal/user"
+	"go-api-template/pkg/secret"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Service is a service provider
+type Service interface {
+	Issue(ctx context.Context, u *user.User, device user.Device) (*user.Tokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*user.Tokens, error)
+	Revoke(ctx context.Context, claims *security.JwtClaims) error
+	RevokeAll(ctx context.Context, userID int) error
+	RevokeRefreshToken(ctx context.Context, userID int, refreshToken string) error
+	IsRevoked(ctx context.Context, claims *security.JwtClaims) (bool, error)
+	ListSessions(ctx context.Context, userID int) ([]Session, error)
+	DeleteSession(ctx context.Context, userID int, id string) error
+}
+
+// Errors that can occur in the service
+var (
+	ErrInternalService     = errors.New("internal service error")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
+// maxUserAgentLen bounds the user agent stored for a session, clients choose it
+const maxUserAgentLen = 512
+
+type service struct {
+	logger          zerolog.Logger
+	repo            Repository
+	users           user.Service
+	tokenGenrator   func(u *user.User, sessionID string) (string, string, error)
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	cache           *revocationCache
+	now             func() time.Time
+}
+
+// Issue starts a session for a login from device
+func (s service) Issue(ctx context.Context, u *user.User, device user.Device) (*user.Tokens, error) {
+	return s.issue(ctx, u, uuid.New().String(), &device)
+}
+
+func (s service) Refresh(ctx context.Context, refreshToken string) (*user.Tokens, error) {
+	t, err := s.repo.FindByHash(ctx, secret.Hash(refreshToken))
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoTokenNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+
+		return nil, ErrInternalService
+	}
+
+	if t.RevokedAt != nil || time.Now().After(t.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if t.UsedAt != nil {
+		return nil, s.reused(ctx, t)
+	}
+
+	err = s.repo.MarkUsed(ctx, t.ID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoTokenAlreadyUsed) {
+			// lost a race against another refresh with the same token
+			return nil, s.reused(ctx, t)
+		}
+
+		return nil, ErrInternalService
+	}
+
+	u, err := s.users.FindByID(ctx, t.UserID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+
+		return nil, ErrInternalService
+	}
+
+	if !u.Active {
+		return nil, user.ErrUserInactive
+	}
+
+	return s.issue(ctx, u, t.FamilyID, nil)
+}
+
+func (s service) Revoke(ctx context.Context, claims *security.JwtClaims) error {
+	if claims.Id == "" {
+		// tokens issued before jti existed can only be revoked all at once
+		return s.RevokeAll(ctx, claims.UserID)
+	}
+
+	expiresAt := time.Unix(claims.ExpiresAt, 0)
+
+	// logging out ends the session the token belongs to
+	if claims.SessionID != "" {
+		err := s.repo.RevokeFamily(ctx, claims.SessionID)
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("")
+			return ErrInternalService
+		}
+	}
+
+	err := s.repo.RevokeToken(ctx, &RevokedToken{
+		JTI:       claims.Id,
+		UserID:    claims.UserID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	s.cache.set(claims.Id, claims.UserID, true, expiresAt)
+
+	return nil
+}
+
+// RevokeAll revokes every token of a user issued until now. Tokens carry
+// their issue time in microseconds, so one issued right after, like the one
+// of an immediate new login, stays valid.
+func (s service) RevokeAll(ctx context.Context, userID int) error {
+	err := s.repo.RevokeUser(ctx, userID, s.now())
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	s.cache.forgetUser(userID)
+
+	return nil
+}
+
+func (s service) RevokeRefreshToken(ctx context.Context, userID int, refreshToken string) error {
+	t, err := s.repo.FindByHash(ctx, secret.Hash(refreshToken))
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoTokenNotFound) {
+			return ErrInvalidRefreshToken
+		}
+
+		return ErrInternalService
+	}
+
+	if t.UserID != userID {
+		return ErrInvalidRefreshToken
+	}
+
+	err = s.repo.RevokeFamily(ctx, t.FamilyID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+func (s service) IsRevoked(ctx context.Context, claims *security.JwtClaims) (bool, error) {
+	if claims.Id != "" {
+		if revoked, ok := s.cache.get(claims.Id); ok {
+			return revoked, nil
+		}
+	}
+
+	revoked, err := s.repo.IsRevoked(ctx, claims.Id, claims.UserID, claims.Issued())
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return false, ErrInternalService
+	}
+
+	// tokens of a session stop working once it ends
+	if !revoked && claims.SessionID != "" {
+		err = s.repo.TouchSession(ctx, claims.SessionID, "")
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("")
+			if !errors.Is(err, errRepoSessionNotFound) {
+				return false, ErrInternalService
+			}
+
+			revoked = true
+		}
+	}
+
+	if claims.Id != "" {
+		s.cache.set(claims.Id, claims.UserID, revoked, time.Unix(claims.ExpiresAt, 0))
+	}
+
+	return revoked, nil
+}
+
+func (s service) ListSessions(ctx context.Context, userID int) ([]Session, error) {
+	sessions, err := s.repo.ListSessions(ctx, userID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession signs a session out. Its refresh tokens are revoked at once,
+// its access tokens as soon as they are checked against the database again.
+func (s service) DeleteSession(ctx context.Context, userID int, id string) error {
+	sess, err := s.repo.DeleteSession(ctx, userID, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoSessionNotFound) {
+			return ErrSessionNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	if sess.JTI != "" {
+		s.cache.set(sess.JTI, userID, true, time.Now().Add(s.accessTokenTTL))
+	}
+
+	return nil
+}
+
+// reused revokes every token descending from the same login as t. A refresh
+// token being presented twice means either the client or an attacker holds a
+// stolen copy, and there is no way to tell which one.
+func (s service) reused(ctx context.Context, t *RefreshToken) error {
+	s.logger.Warn().Int("user_id", t.UserID).Str("family_id", t.FamilyID).Msg("refresh token reuse detected")
+
+	err := s.repo.RevokeFamily(ctx, t.FamilyID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("family_id", t.FamilyID).Msg("")
+		return ErrInternalService
+	}
+
+	return ErrRefreshTokenReused
+}
+
+// issue hands out a token pair for a session. A device starts a new session,
+// without one the session must still exist.
+func (s service) issue(ctx context.Context, u *user.User, sessionID string, device *user.Device) (*user.Tokens, error) {
+	accessToken, jti, err := s.tokenGenrator(u, sessionID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	if device != nil {
+		userAgent := device.UserAgent
+		if len(userAgent) > maxUserAgentLen {
+			userAgent = userAgent[:maxUserAgentLen]
+		}
+
+		err = s.repo.CreateSession(ctx, &Session{
+			ID:        sessionID,
+			UserID:    u.ID,
+			JTI:       jti,
+			UserAgent: userAgent,
+			IP:        device.IP,
+		})
+	} else {
+		err = s.repo.TouchSession(ctx, sessionID, jti)
+	}
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoSessionNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+
+		return nil, ErrInternalService
+	}
+
+	refreshToken, err := secret.Token(secret.DefaultSize)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	_, err = s.repo.Create(ctx, &RefreshToken{
+		UserID:    u.ID,
+		FamilyID:  sessionID,
+		TokenHash: secret.Hash(refreshToken),
+		ExpiresAt: time.Now().Add(s.refreshTokenTTL),
+	})
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+
+	return &user.Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(s.accessTokenTTL.Seconds()),
+	}, nil
+}
+
+// NewService creates a new service
+func NewService(
+	logger zerolog.Logger,
+	repo Repository,
+	users user.Service,
+	tokenGenrator func(u *user.User, sessionID string) (string, string, error),
+	accessTokenTTL time.Duration,
+	refreshTokenTTL time.Duration,
+	revocationCacheTTL time.Duration,
+) Service {
+	return &service{
+		logger:          logger,
+		repo:            repo,
+		users:           users,
+		tokenGenrator:   tokenGenrator,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+		cache:           newRevocationCache(revocationCacheTTL),
+		now:             time.Now,
+	}
+}
@@ -0,0 +1,70 @@
+package token
+
+import (
+	"context"
+	"go-api-template/internal/security"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// revocationRepo keeps user revocations in memory, comparing like the SQL of
+// repo.IsRevoked does
+type revocationRepo struct {
+	Repository
+	before map[int]time.Time
+}
+
+func (r *revocationRepo) RevokeUser(ctx context.Context, userID int, before time.Time) error {
+	r.before[userID] = before
+	return nil
+}
+
+func (r *revocationRepo) IsRevoked(ctx context.Context, jti string, userID int, issuedAt time.Time) (bool, error) {
+	before, ok := r.before[userID]
+	return ok && before.After(issuedAt), nil
+}
+
+func TestRevokeAllCutsOffAtTheMicrosecond(t *testing.T) {
+	ctx := context.Background()
+	repo := &revocationRepo{before: map[int]time.Time{}}
+	s := NewService(zerolog.Nop(), repo, nil, nil, time.Minute, time.Hour, time.Minute).(*service)
+
+	now := time.Date(2021, 3, 1, 12, 0, 0, 500*int(time.Millisecond), time.UTC)
+	s.now = func() time.Time { return now }
+
+	err := s.RevokeAll(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		issued  time.Time
+		precise bool
+		revoked bool
+	}{
+		{"issued earlier in the same second", now.Add(-time.Millisecond), true, true},
+		{"issued right after", now.Add(time.Microsecond), true, false},
+		{"issued a second before", now.Add(-time.Second), true, true},
+		{"without microseconds in the same second", now, false, true},
+		{"without microseconds a second after", now.Add(time.Second), false, false},
+	}
+	for _, tt := range tests {
+		claims := &security.JwtClaims{UserID: 1}
+		if tt.precise {
+			claims.SetIssuedAt(tt.issued)
+		} else {
+			claims.IssuedAt = tt.issued.Unix()
+		}
+
+		revoked, err := s.IsRevoked(ctx, claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if revoked != tt.revoked {
+			t.Errorf("%s: revoked = %v, want %v", tt.name, revoked, tt.revoked)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package token
+
+import (
+	"errors"
+	"go-api-template/internal/openapi"
+	"go-api-template/internal/security"
+	"go-api-template/internal/user"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+// Transport handles transport for service
+type Transport struct {
+	logger  zerolog.Logger
+	srv     Service
+	cookies *security.CookieAuth
+}
+
+// NewTransport creates a new transport
+func NewTransport(
+	logger zerolog.Logger,
+	srv Service,
+	cookies *security.CookieAuth,
+) Transport {
+	return Transport{
+		logger:  logger,
+		srv:     srv,
+		cookies: cookies,
+	}
+}
+
+// RefreshToken rotates a refresh token into a new token pair
+func (h Transport) RefreshToken(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.TokenRefreshRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	var refreshToken string
+	if req.RefreshToken != nil {
+		refreshToken = *req.RefreshToken
+	} else if refreshToken = h.cookies.RefreshToken(c); refreshToken != "" {
+		err = h.cookies.CheckCSRF(c)
+		if err != nil {
+			return err
+		}
+	}
+
+	tokens, err := h.srv.Refresh(ctx, refreshToken)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrInvalidRefreshToken) || errors.Is(err, ErrRefreshTokenReused) {
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		}
+		if errors.Is(err, user.ErrUserInactive) {
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	err = h.cookies.SetTokens(c, tokens)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.TokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	})
+}
+
+// LogoutUser revokes the access token used for the request and ends its
+// session. If a refresh token is passed its whole family is revoked as well.
+func (h Transport) LogoutUser(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.LogoutRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	claims := security.GetClaimFromEchoContext(c)
+
+	if req.RefreshToken == nil {
+		if refreshToken := h.cookies.RefreshToken(c); refreshToken != "" {
+			req.RefreshToken = &refreshToken
+		}
+	}
+
+	if req.RefreshToken != nil {
+		err = h.srv.RevokeRefreshToken(ctx, claims.UserID, *req.RefreshToken)
+		if err != nil {
+			h.logger.Err(err).Msg("")
+			if errors.Is(err, ErrInvalidRefreshToken) {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	err = h.srv.Revoke(ctx, claims)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	h.cookies.Clear(c)
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "logged out",
+	})
+}
+
+// LogoutAllSessions revokes every token of the current user
+func (h Transport) LogoutAllSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	claims := security.GetClaimFromEchoContext(c)
+
+	err := h.srv.RevokeAll(ctx, claims.UserID)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	h.cookies.Clear(c)
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "logged out everywhere",
+	})
+}
+
+// ListSessions returns the sessions of the current user
+func (h Transport) ListSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	claims := security.GetClaimFromEchoContext(c)
+
+	sessions, err := h.srv.ListSessions(ctx, claims.UserID)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	res := openapi.SessionList{
+		Sessions: make([]openapi.Session, 0, len(sessions)),
+	}
+	for _, sess := range sessions {
+		res.Sessions = append(res.Sessions, openapi.Session{
+			Id:         sess.ID,
+			UserAgent:  sess.UserAgent,
+			Ip:         sess.IP,
+			Current:    sess.ID == claims.SessionID,
+			CreatedAt:  sess.CreatedAt,
+			LastSeenAt: sess.LastSeenAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteSession signs a session of the current user out
+func (h Transport) DeleteSession(c echo.Context, id string) error {
+	ctx := c.Request().Context()
+
+	claims := security.GetClaimFromEchoContext(c)
+
+	err := h.srv.DeleteSession(ctx, claims.UserID, id)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrSessionNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	if id == claims.SessionID {
+		h.cookies.Clear(c)
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "session deleted",
+	})
+}
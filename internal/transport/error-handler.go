@@ -1,12 +1,25 @@
 package transport
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog"
 )
 
+// retryError is an error carrying how long to wait before retrying
+type retryError interface {
+	RetryAfter() time.Duration
+}
+
+// retryAfter formats d as the seconds of a Retry-After header, rounded up
+func retryAfter(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
 func errorHandler(logger zerolog.Logger) echo.HTTPErrorHandler {
 	return func(err error, c echo.Context) {
 		logger.Info().Err(err).Msg("Error Handler")
@@ -16,6 +29,11 @@ func errorHandler(logger zerolog.Logger) echo.HTTPErrorHandler {
 				if herr, ok := he.Internal.(*echo.HTTPError); ok {
 					he = herr
 				}
+
+				// tell throttled clients when to come back
+				if rerr, ok := he.Internal.(retryError); ok {
+					c.Response().Header().Set("Retry-After", retryAfter(rerr.RetryAfter()))
+				}
 			}
 		} else {
 			he = &echo.HTTPError{
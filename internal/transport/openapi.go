@@ -1,17 +1,47 @@
 package transport
 
 import (
+	"go-api-template/internal/apikey"
+	"go-api-template/internal/impersonation"
+	"go-api-template/internal/mfa"
+	"go-api-template/internal/oauth"
 	"go-api-template/internal/openapi"
+	"go-api-template/internal/privacy"
+	"go-api-template/internal/token"
 	"go-api-template/internal/user"
 )
 
+// every service names its handlers Transport, aliases let server embed them all
+type (
+	userTransport   = user.Transport
+	tokenTransport  = token.Transport
+	mfaTransport    = mfa.Transport
+	apiKeyTransport = apikey.Transport
+	oauthTransport  = oauth.Transport
+
+	impersonationTransport = impersonation.Transport
+	privacyTransport       = privacy.Transport
+)
+
 type server struct {
-	user.Transport
+	userTransport
+	tokenTransport
+	mfaTransport
+	apiKeyTransport
+	oauthTransport
+	impersonationTransport
+	privacyTransport
 }
 
 // New returns a new OpenAPI Echo Server implementation
-func New(userTransport user.Transport) openapi.ServerInterface {
+func New(userT user.Transport, tokenT token.Transport, mfaT mfa.Transport, apiKeyT apikey.Transport, oauthT oauth.Transport, impersonationT impersonation.Transport, privacyT privacy.Transport) openapi.ServerInterface {
 	return &server{
-		userTransport,
+		userT,
+		tokenT,
+		mfaT,
+		apiKeyT,
+		oauthT,
+		impersonationT,
+		privacyT,
 	}
 }
@@ -9,7 +9,7 @@ import (
 )
 
 // NewEchoEngine returns a new echo server
-func NewEchoEngine(logger zerolog.Logger) *echo.Echo {
+func NewEchoEngine(logger zerolog.Logger, cors middleware.CORSConfig) *echo.Echo {
 	e := echo.New()
 
 	e.HideBanner = true
@@ -29,7 +29,7 @@ func NewEchoEngine(logger zerolog.Logger) *echo.Echo {
 	}) */
 	e.Use(loggingMiddleware(logger))
 	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+	e.Use(middleware.CORSWithConfig(cors))
 
 	return e
 }
@@ -0,0 +1,208 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"go-api-template/internal/audit"
+	"strings"
+	"time"
+)
+
+// Suspend deactivates the account of a user, an admin cannot suspend
+// themselves
+func (s service) Suspend(ctx context.Context, actorID, id int, reason, ip string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrReasonRequired
+	}
+
+	if actorID == id {
+		return ErrSuspendSelf
+	}
+
+	return s.setActive(ctx, actorID, id, false, reason, ip)
+}
+
+// Reactivate activates the account of a suspended user again
+func (s service) Reactivate(ctx context.Context, actorID, id int, reason, ip string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrReasonRequired
+	}
+
+	return s.setActive(ctx, actorID, id, true, reason, ip)
+}
+
+func (s service) setActive(ctx context.Context, actorID, id int, active bool, reason, ip string) error {
+	err := s.repo.SetActive(ctx, id, active)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return ErrUserNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	action := audit.ActionUserReactivate
+	if !active {
+		action = audit.ActionUserSuspend
+	}
+
+	audit.RecordOrLog(ctx, s.audit, s.logger, &audit.Entry{
+		Action:  action,
+		ActorID: actorID,
+		UserID:  id,
+		IP:      ip,
+		Details: map[string]string{
+			"reason": reason,
+		},
+	})
+
+	return nil
+}
+
+// Delete soft deletes the account of a user after checking their password.
+// It can be restored by an admin until the restore window is over.
+func (s service) Delete(ctx context.Context, id int, password, ip string) error {
+	u, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return ErrUserNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	if !s.verifyPassword(ctx, u, password) {
+		return ErrIncorrectPassword
+	}
+
+	err = s.repo.SoftDelete(ctx, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return ErrUserNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	audit.RecordOrLog(ctx, s.audit, s.logger, &audit.Entry{
+		Action:  audit.ActionUserDelete,
+		ActorID: id,
+		UserID:  id,
+		IP:      ip,
+	})
+
+	return nil
+}
+
+// Restore undoes the deletion of an account deleted within the restore window
+func (s service) Restore(ctx context.Context, actorID, id int, reason, ip string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrReasonRequired
+	}
+
+	err := s.repo.Restore(ctx, id, time.Now().Add(-s.cfg.Accounts.RestoreWindow))
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return ErrNotRestorable
+		}
+
+		return ErrInternalService
+	}
+
+	audit.RecordOrLog(ctx, s.audit, s.logger, &audit.Entry{
+		Action:  audit.ActionUserRestore,
+		ActorID: actorID,
+		UserID:  id,
+		IP:      ip,
+		Details: map[string]string{
+			"reason": reason,
+		},
+	})
+
+	return nil
+}
+
+// Purge erases one batch of the accounts deleted longer than the retention
+// ago and returns how many went. The rows are removed along with everything
+// the users own and the audit entries about them lose their personal data.
+func (s service) Purge(ctx context.Context) (int, error) {
+	before := time.Now().Add(-s.cfg.Accounts.Retention)
+
+	ids, err := s.repo.ListPurgeable(ctx, before, s.cfg.Accounts.PurgeBatch)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return 0, ErrInternalService
+	}
+
+	purged := 0
+	for _, id := range ids {
+		key, err := s.repo.AvatarKey(ctx, id)
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("")
+			return purged, ErrInternalService
+		}
+
+		// anonymized first so a failure leaves the user to the next run
+		err = s.audit.Anonymize(ctx, id)
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("")
+			return purged, ErrInternalService
+		}
+
+		err = s.repo.Purge(ctx, id, before)
+		if err != nil {
+			if errors.Is(err, errRepoUserNotFound) {
+				continue
+			}
+
+			s.logger.Error().Err(err).Int("user_id", id).Msg("purge user")
+			return purged, ErrInternalService
+		}
+
+		purged++
+
+		s.deleteAvatar(ctx, key)
+
+		audit.RecordOrLog(ctx, s.audit, s.logger, &audit.Entry{
+			Action: audit.ActionUserPurge,
+			UserID: id,
+		})
+	}
+
+	return purged, nil
+}
+
+// Anonymize erases the personal data of a user but keeps their row, see
+// Repository.Anonymize. Callers revoke the tokens and record the erasure.
+func (s service) Anonymize(ctx context.Context, id int) error {
+	key, err := s.repo.AvatarKey(ctx, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return ErrUserNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	err = s.repo.Anonymize(ctx, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return ErrUserNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	s.deleteAvatar(ctx, key)
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-api-template/pkg/thumbnail"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // decodes gif avatars
+	"image/jpeg"
+	_ "image/png" // decodes png avatars
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// AvatarSizes are the sides in pixels of the square thumbnails an avatar is
+// scaled to, smallest first
+var AvatarSizes = []int{64, 128, 256}
+
+// avatarTypes are the sniffed content types accepted for avatars
+var avatarTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// Thumbnail is a scaled copy of an avatar
+type Thumbnail struct {
+	Size int
+	URL  string
+}
+
+// SetAvatar replaces the avatar of a user with the image read from r. Only
+// thumbnails re-encoded from the decoded pixels are kept, metadata like the
+// location a photo was taken at does not survive.
+func (s service) SetAvatar(ctx context.Context, id int, r io.Reader) (*User, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, s.cfg.Avatar.MaxSize+1))
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInternalService
+	}
+	if int64(len(data)) > s.cfg.Avatar.MaxSize {
+		return nil, ErrAvatarTooLarge
+	}
+
+	// the type claimed by the client is not trusted
+	if !avatarTypes[http.DetectContentType(data)] {
+		return nil, ErrAvatarType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrAvatarType
+	}
+	if cfg.Width*cfg.Height > s.cfg.Avatar.MaxPixels {
+		return nil, ErrAvatarTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrAvatarType
+	}
+
+	u, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+
+		return nil, ErrInternalService
+	}
+
+	// a new key every time, caches of the old urls never show a stale image
+	key := fmt.Sprintf("avatars/%d/%s", id, uuid.New().String())
+
+	for _, size := range AvatarSizes {
+		buf := &bytes.Buffer{}
+		err = jpeg.Encode(buf, opaque(thumbnail.Square(img, size)), &jpeg.Options{Quality: 85})
+		if err == nil {
+			err = s.blob.Put(ctx, thumbnailKey(key, size), buf, int64(buf.Len()), "image/jpeg")
+		}
+		if err != nil {
+			s.logger.Error().Err(err).Int("user_id", id).Msg("store avatar")
+			s.deleteAvatar(ctx, key)
+			return nil, ErrInternalService
+		}
+	}
+
+	old := u.AvatarKey
+	u.AvatarKey = key
+
+	err = s.repo.Update(ctx, u, "avatar_key")
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		s.deleteAvatar(ctx, key)
+		if errors.Is(err, errRepoUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+
+		return nil, ErrInternalService
+	}
+
+	s.deleteAvatar(ctx, old)
+
+	return u, nil
+}
+
+// DeleteAvatar removes the avatar of a user
+func (s service) DeleteAvatar(ctx context.Context, id int) (*User, error) {
+	u, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+
+		return nil, ErrInternalService
+	}
+
+	if u.AvatarKey == "" {
+		return u, nil
+	}
+
+	old := u.AvatarKey
+	u.AvatarKey = ""
+
+	err = s.repo.Update(ctx, u, "avatar_key")
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+
+		return nil, ErrInternalService
+	}
+
+	s.deleteAvatar(ctx, old)
+
+	return u, nil
+}
+
+// ImageURL returns the url of the largest thumbnail of the avatar of u, empty
+// without one
+func (s service) ImageURL(u *User) string {
+	if u.AvatarKey == "" {
+		return ""
+	}
+
+	return s.blob.URL(thumbnailKey(u.AvatarKey, AvatarSizes[len(AvatarSizes)-1]))
+}
+
+// Thumbnails returns every thumbnail of the avatar of u, smallest first
+func (s service) Thumbnails(u *User) []Thumbnail {
+	thumbnails := []Thumbnail{}
+	if u.AvatarKey == "" {
+		return thumbnails
+	}
+
+	for _, size := range AvatarSizes {
+		thumbnails = append(thumbnails, Thumbnail{
+			Size: size,
+			URL:  s.blob.URL(thumbnailKey(u.AvatarKey, size)),
+		})
+	}
+
+	return thumbnails
+}
+
+// deleteAvatar removes the stored thumbnails of an avatar. Failures are only
+// logged, they leave unreferenced objects behind but nothing broken.
+func (s service) deleteAvatar(ctx context.Context, key string) {
+	if key == "" {
+		return
+	}
+
+	for _, size := range AvatarSizes {
+		err := s.blob.Delete(ctx, thumbnailKey(key, size))
+		if err != nil {
+			s.logger.Error().Err(err).Str("key", key).Msg("delete avatar")
+		}
+	}
+}
+
+func thumbnailKey(key string, size int) string {
+	return fmt.Sprintf("%s/%d.jpg", key, size)
+}
+
+// opaque flattens img onto white, JPEG has no transparency
+func opaque(img *image.RGBA) *image.RGBA {
+	dst := image.NewRGBA(img.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	return dst
+}
@@ -0,0 +1,209 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go-api-template/internal/config"
+	"go-api-template/pkg/storage"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// avatarRepo keeps a single user in memory
+type avatarRepo struct {
+	Repository
+	user User
+}
+
+func (r *avatarRepo) FindByID(ctx context.Context, id int) (*User, error) {
+	if id != r.user.ID {
+		return nil, errRepoUserNotFound
+	}
+
+	u := r.user
+	return &u, nil
+}
+
+func (r *avatarRepo) Update(ctx context.Context, u *User, columns ...string) error {
+	if u.ID != r.user.ID {
+		return errRepoUserNotFound
+	}
+
+	r.user = *u
+	return nil
+}
+
+// memoryBlob keeps objects in memory
+type memoryBlob struct {
+	objects map[string][]byte
+}
+
+func (b *memoryBlob) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	b.objects[key] = data
+	return nil
+}
+
+func (b *memoryBlob) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := b.objects[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (b *memoryBlob) Delete(ctx context.Context, key string) error {
+	delete(b.objects, key)
+	return nil
+}
+
+func (b *memoryBlob) URL(key string) string {
+	return "https://cdn.example.com/" + key
+}
+
+func newAvatarService() (*service, *avatarRepo, *memoryBlob) {
+	cfg := &config.Config{}
+	cfg.Avatar.MaxSize = 64 << 10
+	cfg.Avatar.MaxPixels = 1000 * 1000
+
+	repo := &avatarRepo{user: User{ID: 1}}
+	blob := &memoryBlob{objects: map[string][]byte{}}
+
+	return NewService(zerolog.Nop(), repo, nil, nil, nil, nil, blob, cfg).(*service), repo, blob
+}
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	err := png.Encode(buf, img)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestSetAvatarRejects(t *testing.T) {
+	s, repo, blob := newAvatarService()
+
+	// a PNG header in front of garbage is sniffed as PNG but does not decode
+	broken := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"text", []byte("just some text, not an image"), ErrAvatarType},
+		{"svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), ErrAvatarType},
+		{"broken png", broken, ErrAvatarType},
+		{"too many bytes", bytes.Repeat([]byte{0xff}, int(s.cfg.Avatar.MaxSize)+1), ErrAvatarTooLarge},
+		{"too many pixels", encodePNG(t, 1001, 1000), ErrAvatarTooLarge},
+	}
+	for _, tt := range tests {
+		_, err := s.SetAvatar(context.Background(), 1, bytes.NewReader(tt.data))
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.err)
+		}
+	}
+
+	if repo.user.AvatarKey != "" || len(blob.objects) != 0 {
+		t.Errorf("rejected uploads left key %q and %d objects", repo.user.AvatarKey, len(blob.objects))
+	}
+}
+
+func TestSetAvatar(t *testing.T) {
+	ctx := context.Background()
+	s, repo, blob := newAvatarService()
+
+	u, err := s.SetAvatar(ctx, 1, bytes.NewReader(encodePNG(t, 300, 200)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := u.AvatarKey
+	if !strings.HasPrefix(key, "avatars/1/") || repo.user.AvatarKey != key {
+		t.Fatalf("avatar key %q, stored %q", key, repo.user.AvatarKey)
+	}
+
+	if len(blob.objects) != len(AvatarSizes) {
+		t.Errorf("%d objects stored, want %d", len(blob.objects), len(AvatarSizes))
+	}
+
+	for _, size := range AvatarSizes {
+		data, ok := blob.objects[thumbnailKey(key, size)]
+		if !ok {
+			t.Fatalf("no %dpx thumbnail", size)
+		}
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%dpx thumbnail: %v", size, err)
+		}
+		if cfg.Width != size || cfg.Height != size {
+			t.Errorf("%dpx thumbnail is %dx%d", size, cfg.Width, cfg.Height)
+		}
+	}
+
+	thumbnails := s.Thumbnails(u)
+	if len(thumbnails) != len(AvatarSizes) {
+		t.Fatalf("%d thumbnails, want %d", len(thumbnails), len(AvatarSizes))
+	}
+	for i, th := range thumbnails {
+		want := "https://cdn.example.com/" + key + "/" + strconv.Itoa(AvatarSizes[i]) + ".jpg"
+		if th.Size != AvatarSizes[i] || th.URL != want {
+			t.Errorf("thumbnail %d = %+v, want %d at %s", i, th, AvatarSizes[i], want)
+		}
+	}
+
+	// the image url is the largest thumbnail
+	if got, want := s.ImageURL(u), thumbnails[len(thumbnails)-1].URL; got != want {
+		t.Errorf("ImageURL = %s, want %s", got, want)
+	}
+
+	// a new avatar replaces the thumbnails of the old one
+	u, err = s.SetAvatar(ctx, 1, bytes.NewReader(encodePNG(t, 50, 80)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.AvatarKey == key {
+		t.Error("a new avatar reused the old key")
+	}
+	for _, size := range AvatarSizes {
+		if _, ok := blob.objects[thumbnailKey(key, size)]; ok {
+			t.Errorf("old %dpx thumbnail kept", size)
+		}
+	}
+
+	u, err = s.DeleteAvatar(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.AvatarKey != "" || len(blob.objects) != 0 {
+		t.Errorf("after delete key %q and %d objects", u.AvatarKey, len(blob.objects))
+	}
+	if s.ImageURL(u) != "" || len(s.Thumbnails(u)) != 0 {
+		t.Error("a user without avatar has an image url or thumbnails")
+	}
+}
@@ -0,0 +1,227 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"go-api-template/internal/audit"
+	"go-api-template/pkg/mail"
+	"go-api-template/pkg/secret"
+	"go-api-template/pkg/sms"
+	"math/big"
+	netmail "net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// mobilePattern is an international number of up to 15 digits, spaces,
+// dashes and dots are dropped before matching
+var mobilePattern = regexp.MustCompile(`^\+?[0-9]{6,15}$`)
+
+var mobileSeparators = strings.NewReplacer(" ", "", "-", "", ".", "")
+
+// RequestEmailChange sends a confirmation code to a new email of a user after
+// checking their password. The email stays the same until ConfirmEmailChange.
+func (s service) RequestEmailChange(ctx context.Context, id int, password, email string) error {
+	email = strings.TrimSpace(email)
+
+	addr, err := netmail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return ErrInvalidEmail
+	}
+
+	return s.requestContactChange(ctx, id, password, ContactEmail, email)
+}
+
+// RequestMobileChange texts a confirmation code to a new mobile of a user
+// after checking their password. The mobile stays the same until
+// ConfirmMobileChange.
+func (s service) RequestMobileChange(ctx context.Context, id int, password, mobile string) error {
+	mobile = mobileSeparators.Replace(strings.TrimSpace(mobile))
+	if !mobilePattern.MatchString(mobile) {
+		return ErrInvalidMobile
+	}
+
+	return s.requestContactChange(ctx, id, password, ContactMobile, mobile)
+}
+
+// ConfirmEmailChange replaces the email of a user with the pending one once
+// the code mailed to it is entered. The new email counts as verified.
+func (s service) ConfirmEmailChange(ctx context.Context, id int, code, ip string) (*User, error) {
+	return s.confirmContactChange(ctx, id, ContactEmail, code, ip)
+}
+
+// ConfirmMobileChange replaces the mobile of a user with the pending one once
+// the code texted to it is entered.
+func (s service) ConfirmMobileChange(ctx context.Context, id int, code, ip string) (*User, error) {
+	return s.confirmContactChange(ctx, id, ContactMobile, code, ip)
+}
+
+func (s service) requestContactChange(ctx context.Context, id int, password, kind, value string) error {
+	u, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return ErrUserNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	if !s.verifyPassword(ctx, u, password) {
+		return ErrIncorrectPassword
+	}
+
+	current := u.Email
+	if kind == ContactMobile {
+		current = u.Mobile
+	}
+	if value == current {
+		return ErrContactUnchanged
+	}
+
+	// checked again when the change is applied, this only spares the user a
+	// code that could never work
+	inUse, err := s.repo.ContactInUse(ctx, kind, value)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+	if inUse {
+		return ErrContactInUse
+	}
+
+	code, err := changeCode()
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	err = s.repo.SaveContactChange(ctx, &ContactChange{
+		UserID:    id,
+		Kind:      kind,
+		Value:     value,
+		CodeHash:  secret.Hash(code),
+		ExpiresAt: time.Now().Add(s.cfg.Auth.ContactChange.TTL),
+	})
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	if kind == ContactEmail {
+		err = s.mailer.Send(ctx, &mail.Message{
+			To:      value,
+			Subject: "Confirm your new email",
+			Body: fmt.Sprintf(
+				"Hi %s,\n\nEnter the code below to use this email for your account. It expires in %s.\n\n%s\n\nIf you did not ask for this you can ignore this email.",
+				u.FirstName, s.cfg.Auth.ContactChange.TTL, code,
+			),
+		})
+	} else {
+		err = s.sms.Send(ctx, &sms.Message{
+			To:   value,
+			Body: fmt.Sprintf("Your confirmation code is %s. It expires in %s.", code, s.cfg.Auth.ContactChange.TTL),
+		})
+	}
+	if err != nil {
+		s.logger.Error().Err(err).Int("user_id", id).Str("kind", kind).Msg("send contact change code")
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// confirmContactChange applies the pending change of a user once the code
+// sent for it is entered. Every attempt counts, right or wrong.
+func (s service) confirmContactChange(ctx context.Context, id int, kind, code, ip string) (*User, error) {
+	c, err := s.repo.UseContactChangeAttempt(ctx, id, kind, s.cfg.Auth.ContactChange.MaxAttempts)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoChangeNotFound) {
+			return nil, ErrInvalidChangeCode
+		}
+
+		return nil, ErrInternalService
+	}
+
+	if subtle.ConstantTimeCompare([]byte(secret.Hash(strings.TrimSpace(code))), []byte(c.CodeHash)) != 1 {
+		return nil, ErrInvalidChangeCode
+	}
+
+	old, err := s.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.ApplyContactChange(ctx, c)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		switch {
+		case errors.Is(err, errRepoUserAlreadyExists):
+			// another user got the value first, the change can never apply
+			err = s.repo.DeleteContactChange(ctx, c.ID)
+			if err != nil {
+				s.logger.Error().Err(err).Int("user_id", id).Msg("delete contact change")
+			}
+
+			return nil, ErrContactInUse
+		case errors.Is(err, errRepoChangeNotFound):
+			return nil, ErrInvalidChangeCode
+		case errors.Is(err, errRepoUserNotFound):
+			return nil, ErrUserNotFound
+		}
+
+		return nil, ErrInternalService
+	}
+
+	u, err := s.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	action := audit.ActionEmailChange
+	if kind == ContactMobile {
+		action = audit.ActionMobileChange
+	}
+
+	audit.RecordOrLog(ctx, s.audit, s.logger, &audit.Entry{
+		Action:  action,
+		ActorID: id,
+		UserID:  id,
+		IP:      ip,
+	})
+
+	// the old email learns about the change in case the account was taken over
+	subject, what := "Your email was changed", "email"
+	if kind == ContactMobile {
+		subject, what = "Your mobile number was changed", "mobile number"
+	}
+
+	err = s.mailer.Send(ctx, &mail.Message{
+		To:      old.Email,
+		Subject: subject,
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nThe %s of your account was just changed. If this was not you, contact us right away.",
+			old.FirstName, what,
+		),
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Int("user_id", id).Msg("send contact change notice")
+	}
+
+	return u, nil
+}
+
+// changeCode returns a random code of 6 digits
+func changeCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -0,0 +1,83 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"go-api-template/pkg/secret"
+	"time"
+)
+
+// Purposes of signed links, a link signed for one purpose is useless for another
+const (
+	linkVerifyEmail = "verify-email"
+	linkMFAPending  = "mfa-pending"
+	linkMagicLogin  = "magic-login"
+)
+
+var errLinkInvalid = errors.New("invalid or expired link")
+
+// linkClaims is the payload of a signed link mailed to a user. It carries the
+// email it was sent to so that it stops working once the email changes.
+type linkClaims struct {
+	Purpose   string `json:"p"`
+	UserID    int    `json:"u"`
+	Email     string `json:"e"`
+	ExpiresAt int64  `json:"x"`
+	Nonce     string `json:"n,omitempty"`
+}
+
+// signLink returns a tamper proof token for u valid for ttl
+func signLink(key string, purpose string, u *User, ttl time.Duration) (string, error) {
+	return signClaims(key, &linkClaims{
+		Purpose:   purpose,
+		UserID:    u.ID,
+		Email:     u.Email,
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+	})
+}
+
+// signSingleUseLink is signLink with a random nonce, redeeming the link
+// records the nonce so it can not be used again
+func signSingleUseLink(key string, purpose string, u *User, ttl time.Duration) (string, error) {
+	nonce, err := secret.Token(secret.DefaultSize)
+	if err != nil {
+		return "", err
+	}
+
+	return signClaims(key, &linkClaims{
+		Purpose:   purpose,
+		UserID:    u.ID,
+		Email:     u.Email,
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+		Nonce:     nonce,
+	})
+}
+
+func signClaims(key string, claims *linkClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	return secret.Sign([]byte(key), payload), nil
+}
+
+// parseLink checks a token created by signLink for purpose
+func parseLink(key string, purpose string, token string) (*linkClaims, error) {
+	payload, err := secret.Verify([]byte(key), token)
+	if err != nil {
+		return nil, errLinkInvalid
+	}
+
+	claims := &linkClaims{}
+	err = json.Unmarshal(payload, claims)
+	if err != nil {
+		return nil, errLinkInvalid
+	}
+
+	if claims.Purpose != purpose || time.Now().Unix() > claims.ExpiresAt {
+		return nil, errLinkInvalid
+	}
+
+	return claims, nil
+}
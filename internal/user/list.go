@@ -0,0 +1,108 @@
+package user
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Fields users can be listed by
+const (
+	SortCreatedAt = "created_at"
+	SortEmail     = "email"
+	SortFirstName = "first_name"
+	SortLastName  = "last_name"
+)
+
+// Page sizes of user lists
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// ListFilter selects and orders the users of a list. Prefixes match case
+// insensitively, NamePrefix either the first or the last name.
+type ListFilter struct {
+	Active        *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	EmailPrefix   string
+	NamePrefix    string
+	WithDeleted   bool
+
+	Sort  string
+	Desc  bool
+	Limit int
+
+	// After is the last user of the previous page
+	After *Cursor
+}
+
+// Cursor is a position in a list: the sort value and id of a user. Ties on
+// the sort value are broken by id so every position is unique.
+type Cursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d"`
+	Value string `json:"v"`
+	ID    int    `json:"i"`
+}
+
+// sortValue returns the value of the sort field of u as stored in a cursor
+func sortValue(u *User, sort string) string {
+	switch sort {
+	case SortEmail:
+		return u.Email
+	case SortFirstName:
+		return u.FirstName
+	case SortLastName:
+		return u.LastName
+	default:
+		return u.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+func validSort(sort string) bool {
+	switch sort {
+	case SortCreatedAt, SortEmail, SortFirstName, SortLastName:
+		return true
+	}
+
+	return false
+}
+
+// encodeCursor returns the opaque form of c handed to clients
+func encodeCursor(c *Cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses a cursor returned by encodeCursor for a list sorted
+// the same way as f
+func decodeCursor(s string, f *ListFilter) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	c := &Cursor{}
+	err = json.Unmarshal(b, c)
+	if err != nil || c.ID <= 0 || c.Sort != f.Sort || c.Desc != f.Desc {
+		return nil, ErrInvalidCursor
+	}
+
+	if c.Sort == SortCreatedAt {
+		_, err = time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	return c, nil
+}
+
+// likePrefix returns the LIKE pattern matching strings starting with prefix
+func likePrefix(prefix string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(prefix) + "%"
+}
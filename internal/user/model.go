@@ -3,8 +3,15 @@ package user
 import (
 	"context"
 	"time"
+
+	"github.com/go-pg/pg/v10/orm"
 )
 
+func init() {
+	// the join table of many2many relations has to be registered
+	orm.RegisterTable((*UserRole)(nil))
+}
+
 // User is a user in the system
 type User struct {
 	tableName struct{} `pg:"users,alias:users"`
@@ -14,13 +21,19 @@ type User struct {
 	Mobile   string `pg:",unique,notnull" json:"mobile"`
 	Password string `pg:",notnull" json:"-"`
 
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+
 	FirstName string `pg:",notnull" json:"first_name"`
 	LastName  string `pg:",notnull" json:"last_name"`
-	ImageURL  string `pg:",notnull" json:"image_url"`
 	Address   string `pg:",notnull" json:"address"`
 
+	// AvatarKey prefixes the stored thumbnails of the avatar, empty without one
+	AvatarKey string `pg:",notnull,use_zero" json:"-"`
+
 	Active bool `pg:",notnull" json:"active"`
 
+	Roles []Role `pg:"many2many:user_roles" json:"roles"`
+
 	CreatedAt time.Time  `pg:",notnull" json:"created_at"`
 	UpdatedAt time.Time  `pg:",notnull" json:"updated_at"`
 	DeletedAt *time.Time `pg:",soft_delete" json:"-"`
@@ -40,3 +53,140 @@ func (o *User) BeforeUpdate(c context.Context) (context.Context, error) {
 
 	return c, nil
 }
+
+// PasswordReset is a single use token that lets a user pick a new password
+// without knowing the current one. Only a hash of the token is stored.
+type PasswordReset struct {
+	tableName struct{} `pg:"password_resets,alias:password_resets"`
+
+	ID        int       `pg:",pk"`
+	UserID    int       `pg:",notnull"`
+	TokenHash string    `pg:",unique,notnull"`
+	ExpiresAt time.Time `pg:",notnull"`
+
+	CreatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *PasswordReset) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+
+	return c, nil
+}
+
+// Kinds of contact changes
+const (
+	ContactEmail  = "email"
+	ContactMobile = "mobile"
+)
+
+// ContactChange is a new email or mobile waiting for the user to enter the
+// code sent to it. A user has at most one of each kind, only a hash of the
+// code is stored.
+type ContactChange struct {
+	tableName struct{} `pg:"contact_changes,alias:contact_changes"`
+
+	ID        int       `pg:",pk"`
+	UserID    int       `pg:",notnull"`
+	Kind      string    `pg:",notnull"`
+	Value     string    `pg:",notnull"`
+	CodeHash  string    `pg:",notnull"`
+	Attempts  int       `pg:",notnull,use_zero"`
+	ExpiresAt time.Time `pg:",notnull"`
+
+	CreatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *ContactChange) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+
+	return c, nil
+}
+
+// Update is a partial update of a user profile, nil fields are left untouched
+type Update struct {
+	FirstName *string
+	LastName  *string
+	Address   *string
+}
+
+// apply copies the set fields onto u and returns the columns that changed
+func (o *Update) apply(u *User) []string {
+	columns := []string{}
+
+	set := func(column string, dst *string, src *string) {
+		if src != nil {
+			*dst = *src
+			columns = append(columns, column)
+		}
+	}
+
+	set("first_name", &u.FirstName, o.FirstName)
+	set("last_name", &u.LastName, o.LastName)
+	set("address", &u.Address, o.Address)
+
+	return columns
+}
+
+// RoleNames returns the names of the roles of the user
+func (o *User) RoleNames() []string {
+	names := make([]string, 0, len(o.Roles))
+	for _, r := range o.Roles {
+		names = append(names, r.Name)
+	}
+
+	return names
+}
+
+// Permissions returns every permission granted to the user by its roles
+func (o *User) Permissions() []string {
+	seen := map[string]bool{}
+	permissions := []string{}
+	for _, r := range o.Roles {
+		for _, p := range r.Permissions {
+			if !seen[p] {
+				seen[p] = true
+				permissions = append(permissions, p)
+			}
+		}
+	}
+
+	return permissions
+}
+
+// Role is a named set of permissions. Permissions are matched against the
+// scopes operations require in the openapi spec.
+type Role struct {
+	tableName struct{} `pg:"roles,alias:roles"`
+
+	ID          int      `pg:",pk" json:"-"`
+	Name        string   `pg:",unique,notnull" json:"name"`
+	Permissions []string `pg:",array,notnull" json:"permissions"`
+}
+
+// UserRole links users to roles
+type UserRole struct {
+	tableName struct{} `pg:"user_roles,alias:user_roles"`
+
+	UserID int `pg:",pk"`
+	RoleID int `pg:",pk"`
+}
+
+// UsedLink is the nonce of a single use link that was redeemed. It is kept
+// until the link expires.
+type UsedLink struct {
+	tableName struct{} `pg:"used_links,alias:used_links"`
+
+	Nonce     string    `pg:",pk"`
+	ExpiresAt time.Time `pg:",notnull"`
+
+	CreatedAt time.Time `pg:",notnull"`
+}
+
+// BeforeInsert Before insert trigger
+func (o *UsedLink) BeforeInsert(c context.Context) (context.Context, error) {
+	o.CreatedAt = time.Now()
+
+	return c, nil
+}
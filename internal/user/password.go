@@ -0,0 +1,42 @@
+package user
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordCost is the bcrypt cost new password hashes are generated with
+const passwordCost = bcrypt.DefaultCost
+
+// hashDelimiter separates the bcrypt hash from its salt in a stored password
+const hashDelimiter = "||"
+
+var errMalformedHash = errors.New("malformed password hash")
+
+// hashCost returns the bcrypt cost of a stored password hash
+func hashCost(hash string) (int, error) {
+	parts := strings.Split(hash, hashDelimiter)
+	if len(parts) != 2 {
+		return 0, errMalformedHash
+	}
+
+	cost, err := bcrypt.Cost([]byte(parts[0]))
+	if err != nil {
+		return 0, errMalformedHash
+	}
+
+	return cost, nil
+}
+
+// hashNeedsRehash reports whether a stored password hash was generated with
+// weaker parameters than the ones currently in use
+func hashNeedsRehash(hash string) bool {
+	cost, err := hashCost(hash)
+	if err != nil {
+		return true
+	}
+
+	return cost < passwordCost
+}
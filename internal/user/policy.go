@@ -0,0 +1,200 @@
+package user
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"go-api-template/internal/config"
+	"go-api-template/pkg/bloom"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Codes of the rules a field can break
+const (
+	CodeRequired     = "required"
+	CodeTooShort     = "too_short"
+	CodeTooLong      = "too_long"
+	CodeTooSimple    = "too_simple"
+	CodePersonalInfo = "personal_info"
+	CodeBreached     = "breached"
+)
+
+// minPersonalInfoLen keeps short names from ruling out most passwords
+const minPersonalInfoLen = 3
+
+// FieldError is a rule a field of a request broke
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+// ValidationError is returned when fields of a request break rules. It
+// matches ErrInvalidPassword when the password is one of them.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		messages = append(messages, f.Field+": "+f.Message)
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// Is reports whether target is ErrInvalidPassword and the password broke a rule
+func (e *ValidationError) Is(target error) bool {
+	if target != ErrInvalidPassword {
+		return false
+	}
+
+	for _, f := range e.Fields {
+		if f.Field == "password" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BreachedPasswords tells whether a password is known to be compromised
+type BreachedPasswords interface {
+	Contains(password string) bool
+}
+
+type breachedFilter struct {
+	filter *bloom.Filter
+}
+
+// OpenBreachedPasswords loads the bloom filter of the SHA-1 hashes of
+// compromised passwords at path, built with cmd/breach. An empty path checks
+// nothing.
+func OpenBreachedPasswords(path string) (BreachedPasswords, error) {
+	if path == "" {
+		return noBreachedPasswords{}, nil
+	}
+
+	f, err := bloom.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return breachedFilter{filter: f}, nil
+}
+
+func (b breachedFilter) Contains(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	return b.filter.Test(sum[:])
+}
+
+type noBreachedPasswords struct{}
+
+func (noBreachedPasswords) Contains(string) bool {
+	return false
+}
+
+// passwordPolicy are the rules new passwords must follow
+type passwordPolicy struct {
+	minLength  int
+	maxLength  int
+	minClasses int
+	breached   BreachedPasswords
+}
+
+func newPasswordPolicy(cfg *config.Config, breached BreachedPasswords) passwordPolicy {
+	if breached == nil {
+		breached = noBreachedPasswords{}
+	}
+
+	return passwordPolicy{
+		minLength:  cfg.Auth.Password.MinLength,
+		maxLength:  cfg.Auth.Password.MaxLength,
+		minClasses: cfg.Auth.Password.MinClasses,
+		breached:   breached,
+	}
+}
+
+// check returns a *ValidationError listing the rules password breaks, the
+// details of u it must not contain may be partial
+func (p passwordPolicy) check(password string, u *User) error {
+	var fields []FieldError
+	fail := func(code, message string) {
+		fields = append(fields, FieldError{
+			Field:   "password",
+			Code:    code,
+			Message: message,
+		})
+	}
+
+	length := utf8.RuneCountInString(password)
+	switch {
+	case length == 0:
+		fail(CodeRequired, "password is required")
+	case length < p.minLength:
+		fail(CodeTooShort, fmt.Sprintf("password must have at least %d characters", p.minLength))
+	case p.maxLength > 0 && length > p.maxLength:
+		fail(CodeTooLong, fmt.Sprintf("password must have at most %d characters", p.maxLength))
+	}
+
+	if length > 0 && characterClasses(password) < p.minClasses {
+		fail(CodeTooSimple, fmt.Sprintf("password must mix at least %d of lowercase letters, uppercase letters, digits and symbols", p.minClasses))
+	}
+
+	if length > 0 && containsPersonalInfo(password, u) {
+		fail(CodePersonalInfo, "password must not contain your name or email")
+	}
+
+	if length > 0 && p.breached.Contains(password) {
+		fail(CodeBreached, "password appeared in a data breach, pick another one")
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+
+	return nil
+}
+
+// characterClasses counts the classes of characters password mixes
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+
+	return lower + upper + digit + symbol
+}
+
+func containsPersonalInfo(password string, u *User) bool {
+	if u == nil {
+		return false
+	}
+
+	password = strings.ToLower(password)
+
+	info := []string{u.Email, u.FirstName, u.LastName}
+	if i := strings.IndexByte(u.Email, '@'); i > 0 {
+		info = append(info, u.Email[:i])
+	}
+
+	for _, s := range info {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if utf8.RuneCountInString(s) >= minPersonalInfoLen && strings.Contains(password, s) {
+			return true
+		}
+	}
+
+	return false
+}
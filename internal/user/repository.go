@@ -3,8 +3,11 @@ package user
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
 	"github.com/rs/zerolog"
 )
 
@@ -13,11 +16,39 @@ type Repository interface {
 	Create(ctx context.Context, u *User) (*User, error)
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	FindByID(ctx context.Context, id int) (*User, error)
+	List(ctx context.Context, f *ListFilter) ([]User, error)
+	Update(ctx context.Context, u *User, columns ...string) error
+	UpdatePassword(ctx context.Context, id int, password string) error
+	CreatePasswordReset(ctx context.Context, pr *PasswordReset) error
+	FindPasswordReset(ctx context.Context, hash string) (int, error)
+	ConsumePasswordReset(ctx context.Context, hash string) (int, error)
+	DeletePasswordResets(ctx context.Context, userID int) error
+	UseLink(ctx context.Context, l *UsedLink) error
+	FindRoleByName(ctx context.Context, name string) (*Role, error)
+	AddRole(ctx context.Context, userID int, roleID int) error
+	RemoveRole(ctx context.Context, userID int, roleID int) error
+	SetActive(ctx context.Context, id int, active bool) error
+	SoftDelete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int, deletedAfter time.Time) error
+	ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]int, error)
+	Purge(ctx context.Context, id int, deletedBefore time.Time) error
+	Anonymize(ctx context.Context, id int) error
+	AvatarKey(ctx context.Context, id int) (string, error)
+	ContactInUse(ctx context.Context, kind, value string) (bool, error)
+	SaveContactChange(ctx context.Context, c *ContactChange) error
+	UseContactChangeAttempt(ctx context.Context, userID int, kind string, maxAttempts int) (*ContactChange, error)
+	DeleteContactChange(ctx context.Context, id int) error
+	ApplyContactChange(ctx context.Context, c *ContactChange) error
 }
 
 var (
 	errRepoUserAlreadyExists = errors.New("user already exists")
 	errRepoUserNotFound      = errors.New("user not found")
+	errRepoRoleNotFound      = errors.New("role not found")
+	errRepoUserRoleNotFound  = errors.New("user does not have role")
+	errRepoResetNotFound     = errors.New("password reset not found")
+	errRepoLinkUsed          = errors.New("link already used")
+	errRepoChangeNotFound    = errors.New("contact change not found")
 )
 
 type repo struct {
@@ -45,7 +76,7 @@ func (r repo) Create(ctx context.Context, u *User) (*User, error) {
 func (r repo) FindByEmail(ctx context.Context, email string) (*User, error) {
 	u := &User{}
 
-	err := r.db.ModelContext(ctx, u).Where("email = ?", email).First()
+	err := r.db.ModelContext(ctx, u).Relation("Roles").Where("email = ?", email).First()
 	if err != nil {
 		r.logger.Debug().Err(err).Msg("")
 		if errors.Is(err, pg.ErrNoRows) {
@@ -60,7 +91,7 @@ func (r repo) FindByEmail(ctx context.Context, email string) (*User, error) {
 func (r repo) FindByID(ctx context.Context, id int) (*User, error) {
 	u := &User{}
 
-	err := r.db.ModelContext(ctx, u).Where("id = ?", id).First()
+	err := r.db.ModelContext(ctx, u).Relation("Roles").Where("id = ?", id).First()
 	if err != nil {
 		r.logger.Debug().Err(err).Msg("")
 		if errors.Is(err, pg.ErrNoRows) {
@@ -72,6 +103,556 @@ func (r repo) FindByID(ctx context.Context, id int) (*User, error) {
 	return u, nil
 }
 
+// List returns a page of users. The filter must have a valid sort field and
+// a limit, one more user than the limit is asked for by callers wanting to
+// know whether another page follows.
+func (r repo) List(ctx context.Context, f *ListFilter) ([]User, error) {
+	users := []User{}
+
+	q := r.db.ModelContext(ctx, &users).Relation("Roles")
+
+	if f.WithDeleted {
+		q = q.AllWithDeleted()
+	}
+	if f.Active != nil {
+		q = q.Where("users.active = ?", *f.Active)
+	}
+	if f.CreatedAfter != nil {
+		q = q.Where("users.created_at >= ?", *f.CreatedAfter)
+	}
+	if f.CreatedBefore != nil {
+		q = q.Where("users.created_at < ?", *f.CreatedBefore)
+	}
+	if f.EmailPrefix != "" {
+		q = q.Where("users.email ILIKE ?", likePrefix(f.EmailPrefix))
+	}
+	if f.NamePrefix != "" {
+		pattern := likePrefix(f.NamePrefix)
+		q = q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			return q.Where("users.first_name ILIKE ?", pattern).
+				WhereOr("users.last_name ILIKE ?", pattern), nil
+		})
+	}
+
+	column := pg.Ident("users." + f.Sort)
+	op, dir := ">", "ASC"
+	if f.Desc {
+		op, dir = "<", "DESC"
+	}
+
+	if f.After != nil {
+		var value interface{} = f.After.Value
+		if f.Sort == SortCreatedAt {
+			// checked when the cursor was decoded
+			value, _ = time.Parse(time.RFC3339Nano, f.After.Value)
+		}
+
+		q = q.Where("(?, users.id) "+op+" (?, ?)", column, value, f.After.ID)
+	}
+
+	err := q.OrderExpr("? "+dir+", users.id "+dir, column).
+		Limit(f.Limit).
+		Select()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// Update writes the given columns of u, updated_at is always written
+func (r repo) Update(ctx context.Context, u *User, columns ...string) error {
+	res, err := r.db.ModelContext(ctx, u).Column(append(columns, "updated_at")...).WherePK().Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		pgErr, ok := err.(pg.Error)
+		if ok && pgErr.IntegrityViolation() {
+			return errRepoUserAlreadyExists
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoUserNotFound
+	}
+
+	return nil
+}
+
+func (r repo) UpdatePassword(ctx context.Context, id int, password string) error {
+	u := &User{
+		ID:       id,
+		Password: password,
+	}
+
+	res, err := r.db.ModelContext(ctx, u).Column("password", "updated_at").WherePK().Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoUserNotFound
+	}
+
+	return nil
+}
+
+func (r repo) CreatePasswordReset(ctx context.Context, pr *PasswordReset) error {
+	_, err := r.db.ModelContext(ctx, pr).Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// FindPasswordReset returns the user id of an unexpired reset
+func (r repo) FindPasswordReset(ctx context.Context, hash string) (int, error) {
+	pr := &PasswordReset{}
+
+	err := r.db.ModelContext(ctx, pr).
+		Where("token_hash = ?", hash).
+		Where("expires_at > ?", time.Now()).
+		First()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, pg.ErrNoRows) {
+			return 0, errRepoResetNotFound
+		}
+		return 0, err
+	}
+
+	return pr.UserID, nil
+}
+
+// ConsumePasswordReset deletes an unexpired reset and returns its user id,
+// so a reset can only ever be used once
+func (r repo) ConsumePasswordReset(ctx context.Context, hash string) (int, error) {
+	pr := &PasswordReset{}
+
+	res, err := r.db.ModelContext(ctx, pr).
+		Where("token_hash = ?", hash).
+		Where("expires_at > ?", time.Now()).
+		Returning("user_id").
+		Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return 0, err
+	}
+
+	if res.RowsAffected() == 0 {
+		return 0, errRepoResetNotFound
+	}
+
+	return pr.UserID, nil
+}
+
+func (r repo) DeletePasswordResets(ctx context.Context, userID int) error {
+	_, err := r.db.ModelContext(ctx, (*PasswordReset)(nil)).Where("user_id = ?", userID).Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// UseLink records the redemption of a single use link, only the first caller
+// succeeds. Links that expired are forgotten on the way.
+func (r repo) UseLink(ctx context.Context, l *UsedLink) error {
+	_, err := r.db.ModelContext(ctx, (*UsedLink)(nil)).
+		Where("expires_at < ?", time.Now()).
+		Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	res, err := r.db.ModelContext(ctx, l).OnConflict("DO NOTHING").Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoLinkUsed
+	}
+
+	return nil
+}
+
+func (r repo) FindRoleByName(ctx context.Context, name string) (*Role, error) {
+	role := &Role{}
+
+	err := r.db.ModelContext(ctx, role).Where("name = ?", name).First()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, errRepoRoleNotFound
+		}
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (r repo) AddRole(ctx context.Context, userID int, roleID int) error {
+	_, err := r.db.ModelContext(ctx, &UserRole{
+		UserID: userID,
+		RoleID: roleID,
+	}).OnConflict("DO NOTHING").Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		pgErr, ok := err.(pg.Error)
+		if ok && pgErr.IntegrityViolation() {
+			return errRepoUserNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// RemoveRole takes a role away from a user, unknown users and users without
+// the role are not found
+func (r repo) RemoveRole(ctx context.Context, userID int, roleID int) error {
+	res, err := r.db.ModelContext(ctx, (*UserRole)(nil)).
+		Where("user_id = ?", userID).
+		Where("role_id = ?", roleID).
+		Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoUserRoleNotFound
+	}
+
+	return nil
+}
+
+// SetActive suspends or reactivates a user that is not deleted
+func (r repo) SetActive(ctx context.Context, id int, active bool) error {
+	// a false active would be written as NULL through the model
+	res, err := r.db.ModelContext(ctx, (*User)(nil)).
+		Set("active = ?", active).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoUserNotFound
+	}
+
+	return nil
+}
+
+// SoftDelete marks a user as deleted, the row stays until it is purged
+func (r repo) SoftDelete(ctx context.Context, id int) error {
+	res, err := r.db.ModelContext(ctx, &User{ID: id}).WherePK().Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoUserNotFound
+	}
+
+	return nil
+}
+
+// Restore undeletes a user deleted after deletedAfter
+func (r repo) Restore(ctx context.Context, id int, deletedAfter time.Time) error {
+	res, err := r.db.ModelContext(ctx, (*User)(nil)).
+		Deleted().
+		Set("deleted_at = NULL").
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("deleted_at > ?", deletedAfter).
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoUserNotFound
+	}
+
+	return nil
+}
+
+// ListPurgeable returns the ids of up to limit users deleted before
+// deletedBefore, oldest first
+func (r repo) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]int, error) {
+	ids := []int{}
+
+	err := r.db.ModelContext(ctx, (*User)(nil)).
+		Deleted().
+		Column("id").
+		Where("deleted_at <= ?", deletedBefore).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Select(&ids)
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Purge removes the row of a user deleted before deletedBefore, everything
+// it owns goes with it through the foreign keys. A user restored meanwhile
+// is left alone.
+func (r repo) Purge(ctx context.Context, id int, deletedBefore time.Time) error {
+	res, err := r.db.ModelContext(ctx, (*User)(nil)).
+		Where("id = ?", id).
+		Where("deleted_at <= ?", deletedBefore).
+		ForceDelete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errRepoUserNotFound
+	}
+
+	return nil
+}
+
+// anonymizedTables hold personal data or credentials of a user and are
+// emptied of their rows when the user is anonymized. Some belong to other
+// packages, so they are named here.
+var anonymizedTables = []string{
+	"password_resets",
+	"contact_changes",
+	"mfa_secrets",
+	"mfa_recovery_codes",
+	"api_keys",
+	"oauth_consents",
+}
+
+// Anonymize overwrites the personal data of a user, deleted or not, and makes
+// the account unusable. The row is kept so everything referencing it stays
+// valid. Email and mobile are unique, so they get placeholders built from the
+// id. Pending contact changes, second factors, API keys and consents go in
+// the same transaction.
+func (r repo) Anonymize(ctx context.Context, id int) error {
+	err := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		res, err := tx.ModelContext(ctx, (*User)(nil)).
+			AllWithDeleted().
+			Set("email = ?", fmt.Sprintf("erased-%d@invalid", id)).
+			Set("mobile = ?", fmt.Sprintf("erased-%d", id)).
+			Set("password = ''").
+			Set("email_verified_at = NULL").
+			Set("first_name = ''").
+			Set("last_name = ''").
+			Set("avatar_key = ''").
+			Set("address = ''").
+			Set("active = FALSE").
+			Set("updated_at = ?", time.Now()).
+			Where("id = ?", id).
+			Update()
+		if err != nil {
+			return err
+		}
+
+		if res.RowsAffected() == 0 {
+			return errRepoUserNotFound
+		}
+
+		for _, table := range anonymizedTables {
+			_, err = tx.ExecContext(ctx, `DELETE FROM ? WHERE "user_id" = ?`, pg.Ident(table), id)
+			if err != nil {
+				return fmt.Errorf("delete %s: %w", table, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// AvatarKey returns the avatar key of a user, deleted or not
+func (r repo) AvatarKey(ctx context.Context, id int) (string, error) {
+	u := &User{}
+
+	err := r.db.ModelContext(ctx, u).
+		AllWithDeleted().
+		Column("avatar_key").
+		Where("id = ?", id).
+		Select()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, pg.ErrNoRows) {
+			return "", errRepoUserNotFound
+		}
+		return "", err
+	}
+
+	return u.AvatarKey, nil
+}
+
+// contactColumns are the columns of users a contact change kind replaces
+var contactColumns = map[string]string{
+	ContactEmail:  "email",
+	ContactMobile: "mobile",
+}
+
+// ContactInUse reports whether any user, deleted ones included as they keep
+// their unique values until purged, has value as their email or mobile
+func (r repo) ContactInUse(ctx context.Context, kind, value string) (bool, error) {
+	column, ok := contactColumns[kind]
+	if !ok {
+		return false, fmt.Errorf("unknown contact kind %s", kind)
+	}
+
+	exists, err := r.db.ModelContext(ctx, (*User)(nil)).
+		AllWithDeleted().
+		Where("? = ?", pg.Ident(column), value).
+		Exists()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// SaveContactChange stores a pending change, replacing the one of the same
+// kind the user may already have along with its attempts
+func (r repo) SaveContactChange(ctx context.Context, c *ContactChange) error {
+	_, err := r.db.ModelContext(ctx, c).
+		OnConflict("(user_id, kind) DO UPDATE").
+		Set("value = EXCLUDED.value").
+		Set("code_hash = EXCLUDED.code_hash").
+		Set("attempts = 0").
+		Set("expires_at = EXCLUDED.expires_at").
+		Set("created_at = EXCLUDED.created_at").
+		Returning("id").
+		Insert()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// UseContactChangeAttempt counts an attempt at the code of the unexpired
+// change of a user and returns it. Once maxAttempts were used the change is
+// not found anymore, counting before comparing keeps parallel guesses within
+// the limit.
+func (r repo) UseContactChangeAttempt(ctx context.Context, userID int, kind string, maxAttempts int) (*ContactChange, error) {
+	c := &ContactChange{}
+
+	res, err := r.db.ModelContext(ctx, c).
+		Set("attempts = attempts + 1").
+		Where("user_id = ?", userID).
+		Where("kind = ?", kind).
+		Where("attempts < ?", maxAttempts).
+		Where("expires_at > ?", time.Now()).
+		Returning("*").
+		Update()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return nil, err
+	}
+
+	if res.RowsAffected() == 0 {
+		return nil, errRepoChangeNotFound
+	}
+
+	return c, nil
+}
+
+func (r repo) DeleteContactChange(ctx context.Context, id int) error {
+	_, err := r.db.ModelContext(ctx, (*ContactChange)(nil)).Where("id = ?", id).Delete()
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("")
+		return err
+	}
+
+	return nil
+}
+
+// ApplyContactChange swaps the email or mobile of a user for the confirmed
+// value of c and deletes c, so a change is only ever applied once. A new
+// email counts as verified and drops the reset links sent to the old one.
+// When another user took the value since the change was asked for the unique
+// constraint fails and nothing is written.
+func (r repo) ApplyContactChange(ctx context.Context, c *ContactChange) error {
+	column, ok := contactColumns[c.Kind]
+	if !ok {
+		return fmt.Errorf("unknown contact kind %s", c.Kind)
+	}
+
+	return r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		res, err := tx.ModelContext(ctx, (*ContactChange)(nil)).Where("id = ?", c.ID).Delete()
+		if err != nil {
+			r.logger.Debug().Err(err).Msg("")
+			return err
+		}
+
+		if res.RowsAffected() == 0 {
+			return errRepoChangeNotFound
+		}
+
+		now := time.Now()
+
+		q := tx.ModelContext(ctx, (*User)(nil)).
+			Set("? = ?", pg.Ident(column), c.Value).
+			Set("updated_at = ?", now).
+			Where("id = ?", c.UserID)
+		if c.Kind == ContactEmail {
+			q = q.Set("email_verified_at = ?", now)
+		}
+
+		res, err = q.Update()
+		if err != nil {
+			r.logger.Debug().Err(err).Msg("")
+			pgErr, ok := err.(pg.Error)
+			if ok && pgErr.IntegrityViolation() {
+				return errRepoUserAlreadyExists
+			}
+
+			return err
+		}
+
+		if res.RowsAffected() == 0 {
+			return errRepoUserNotFound
+		}
+
+		if c.Kind == ContactEmail {
+			_, err = tx.ModelContext(ctx, (*PasswordReset)(nil)).Where("user_id = ?", c.UserID).Delete()
+			if err != nil {
+				r.logger.Debug().Err(err).Msg("")
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 // NewRepository creates a new repository
 func NewRepository(
 	logger zerolog.Logger,
@@ -3,6 +3,16 @@ package user
 import (
 	"context"
 	"errors"
+	"fmt"
+	"go-api-template/internal/audit"
+	"go-api-template/internal/config"
+	"go-api-template/pkg/mail"
+	"go-api-template/pkg/secret"
+	"go-api-template/pkg/sms"
+	"go-api-template/pkg/storage"
+	"io"
+	"net/url"
+	"time"
 
 	pass "github.com/dev681999/go-pass"
 	"github.com/rs/zerolog"
@@ -10,26 +20,154 @@ import (
 
 // Service is a service provider
 type Service interface {
+	Authenticate(ctx context.Context, email, password string) (*User, error)
 	Create(ctx context.Context, u *User) (*User, error)
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	FindByID(ctx context.Context, id int) (*User, error)
+	List(ctx context.Context, f *ListFilter, cursor string) ([]User, string, error)
+	Update(ctx context.Context, id int, upd *Update) (*User, error)
+	ChangePassword(ctx context.Context, id int, current, password string) error
+	VerifyPassword(ctx context.Context, id int, password string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) (int, error)
+	SendEmailVerification(ctx context.Context, id int) error
+	VerifyEmail(ctx context.Context, token string) error
+	IssueMFAToken(u *User) (string, error)
+	ParseMFAToken(ctx context.Context, token string) (*User, error)
+	SendMagicLink(ctx context.Context, email string) error
+	RedeemMagicLink(ctx context.Context, token string) (*User, error)
+	AssignRole(ctx context.Context, id int, role string) error
+	RemoveRole(ctx context.Context, id int, role string) error
+	Suspend(ctx context.Context, actorID, id int, reason, ip string) error
+	Reactivate(ctx context.Context, actorID, id int, reason, ip string) error
+	Delete(ctx context.Context, id int, password, ip string) error
+	Restore(ctx context.Context, actorID, id int, reason, ip string) error
+	Purge(ctx context.Context) (int, error)
+	Anonymize(ctx context.Context, id int) error
+	SetAvatar(ctx context.Context, id int, r io.Reader) (*User, error)
+	DeleteAvatar(ctx context.Context, id int) (*User, error)
+	ImageURL(u *User) string
+	Thumbnails(u *User) []Thumbnail
+	RequestEmailChange(ctx context.Context, id int, password, email string) error
+	ConfirmEmailChange(ctx context.Context, id int, code, ip string) (*User, error)
+	RequestMobileChange(ctx context.Context, id int, password, mobile string) error
+	ConfirmMobileChange(ctx context.Context, id int, code, ip string) (*User, error)
 }
 
 // Errors that can occur in the service
 var (
-	ErrInvalidPassword   = errors.New("invalid password")
-	ErrInternalService   = errors.New("internal service error")
-	ErrUserAlreadyExists = errors.New("user already exists")
-	ErrUserNotFound      = errors.New("user not found")
+	ErrInvalidPassword    = errors.New("invalid password")
+	ErrInternalService    = errors.New("internal service error")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrIncorrectPassword  = errors.New("current password is incorrect")
+	ErrInvalidResetToken  = errors.New("invalid or expired password reset token")
+	ErrInvalidVerifyToken = errors.New("invalid or expired email verification token")
+	ErrEmailNotVerified   = errors.New("email not verified")
+	ErrEmailVerified      = errors.New("email already verified")
+	ErrInvalidMFAToken    = errors.New("invalid or expired mfa token")
+	ErrInvalidMagicLink   = errors.New("invalid, expired or used login link")
+	ErrMagicLinkDisabled  = errors.New("login links are disabled")
+	ErrRoleNotFound       = errors.New("role not found")
+	ErrUserRoleNotFound   = errors.New("user not found or does not have the role")
+	ErrUserAlreadyExists  = errors.New("user already exists")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidCursor      = errors.New("invalid cursor")
+	ErrInvalidSort        = errors.New("invalid sort field")
+	ErrUserInactive       = errors.New("account is suspended")
+	ErrReasonRequired     = errors.New("a reason is required")
+	ErrSuspendSelf        = errors.New("cannot suspend yourself")
+	ErrNotRestorable      = errors.New("user is not deleted or can no longer be restored")
+	ErrAvatarTooLarge     = errors.New("avatar is too large")
+	ErrAvatarType         = errors.New("avatar must be a jpeg, png or gif image")
+	ErrInvalidEmail       = errors.New("invalid email")
+	ErrInvalidMobile      = errors.New("invalid mobile number")
+	ErrContactUnchanged   = errors.New("new value is the current one")
+	ErrContactInUse       = errors.New("already used by another account")
+	ErrInvalidChangeCode  = errors.New("invalid or expired confirmation code")
 )
 
 type service struct {
 	logger zerolog.Logger
 	repo   Repository
+	mailer mail.Mailer
+	sms    sms.SMSSender
+	audit  audit.Service
+	blob   storage.Blob
+	cfg    *config.Config
+	policy passwordPolicy
 	pass.Hash
+
+	// dummyHash is compared against when no user exists for an email so that
+	// Authenticate takes the same time for known and unknown emails
+	dummyHash string
+}
+
+func (s service) Authenticate(ctx context.Context, email, password string) (*User, error) {
+	u, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			_ = s.Compare(s.dummyHash, password)
+			return nil, ErrInvalidCredentials
+		}
+
+		return nil, ErrInternalService
+	}
+
+	if !s.verifyPassword(ctx, u, password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	u.Password = ""
+
+	return u, nil
+}
+
+// verifyPassword checks password against the stored hash of u, upgrading the
+// hash when it is outdated
+func (s service) verifyPassword(ctx context.Context, u *User, password string) bool {
+	// Compare treats any bcrypt error other than a mismatch as success, so a
+	// malformed stored hash must never reach it
+	if _, err := hashCost(u.Password); err != nil {
+		s.logger.Error().Err(err).Int("user_id", u.ID).Msg("")
+		_ = s.Compare(s.dummyHash, password)
+		return false
+	}
+
+	err := s.Compare(u.Password, password)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return false
+	}
+
+	if hashNeedsRehash(u.Password) {
+		s.rehash(ctx, u.ID, password)
+	}
+
+	return true
+}
+
+// rehash stores a fresh hash for a password that was just verified. Failures
+// are only logged as the old hash is still usable.
+func (s service) rehash(ctx context.Context, id int, password string) {
+	hash, err := s.Generate(password)
+	if err != nil {
+		s.logger.Error().Err(err).Int("user_id", id).Msg("rehash password")
+		return
+	}
+
+	err = s.repo.UpdatePassword(ctx, id, hash)
+	if err != nil {
+		s.logger.Error().Err(err).Int("user_id", id).Msg("rehash password")
+	}
 }
 
 func (s service) Create(ctx context.Context, u *User) (*User, error) {
+	err := s.policy.check(u.Password, u)
+	if err != nil {
+		return nil, err
+	}
+
 	password, err := s.Generate(u.Password)
 	if err != nil {
 		s.logger.Debug().Err(err).Msg("")
@@ -49,6 +187,12 @@ func (s service) Create(ctx context.Context, u *User) (*User, error) {
 		return nil, ErrInternalService
 	}
 
+	// the account exists either way, the user can ask for another email
+	err = s.sendEmailVerification(ctx, u)
+	if err != nil {
+		s.logger.Error().Err(err).Int("user_id", u.ID).Msg("send email verification")
+	}
+
 	return u, nil
 }
 
@@ -84,13 +228,512 @@ func (s service) FindByID(ctx context.Context, id int) (*User, error) {
 	return u, nil
 }
 
+func (s service) Update(ctx context.Context, id int, upd *Update) (*User, error) {
+	u, err := s.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := upd.apply(u)
+	if len(columns) == 0 {
+		return u, nil
+	}
+
+	err = s.repo.Update(ctx, u, columns...)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+
+		return nil, ErrInternalService
+	}
+
+	return u, nil
+}
+
+// List returns a page of users and the cursor of the next page, empty on
+// the last page
+func (s service) List(ctx context.Context, f *ListFilter, cursor string) ([]User, string, error) {
+	if f.Sort == "" {
+		f.Sort = SortCreatedAt
+	}
+	if !validSort(f.Sort) {
+		return nil, "", ErrInvalidSort
+	}
+
+	if f.Limit <= 0 {
+		f.Limit = DefaultListLimit
+	}
+	if f.Limit > MaxListLimit {
+		f.Limit = MaxListLimit
+	}
+
+	if cursor != "" {
+		after, err := decodeCursor(cursor, f)
+		if err != nil {
+			return nil, "", err
+		}
+		f.After = after
+	}
+
+	limit := f.Limit
+	f.Limit++
+
+	users, err := s.repo.List(ctx, f)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, "", ErrInternalService
+	}
+
+	if len(users) <= limit {
+		return users, "", nil
+	}
+
+	users = users[:limit]
+	last := &users[limit-1]
+
+	return users, encodeCursor(&Cursor{
+		Sort:  f.Sort,
+		Desc:  f.Desc,
+		Value: sortValue(last, f.Sort),
+		ID:    last.ID,
+	}), nil
+}
+
+func (s service) ChangePassword(ctx context.Context, id int, current, password string) error {
+	u, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return ErrUserNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	if !s.verifyPassword(ctx, u, current) {
+		return ErrIncorrectPassword
+	}
+
+	err = s.policy.check(password, u)
+	if err != nil {
+		return err
+	}
+
+	return s.setPassword(ctx, id, password)
+}
+
+// VerifyPassword checks the current password of a user, for changes that
+// must not be made with an access token alone
+func (s service) VerifyPassword(ctx context.Context, id int, password string) error {
+	u, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return ErrUserNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	if !s.verifyPassword(ctx, u, password) {
+		return ErrIncorrectPassword
+	}
+
+	return nil
+}
+
+// RequestPasswordReset mails a reset link to email. Unknown emails are not
+// reported so the endpoint cannot be used to find out who has an account.
+func (s service) RequestPasswordReset(ctx context.Context, email string) error {
+	u, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return nil
+		}
+
+		return ErrInternalService
+	}
+
+	token, err := secret.Token(secret.DefaultSize)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	err = s.repo.CreatePasswordReset(ctx, &PasswordReset{
+		UserID:    u.ID,
+		TokenHash: secret.Hash(token),
+		ExpiresAt: time.Now().Add(s.cfg.Auth.PasswordResetTTL),
+	})
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	err = s.mailer.Send(ctx, &mail.Message{
+		To:      u.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to pick a new password. It expires in %s.\n\n%s/reset-password?token=%s\n\nIf you did not ask for this you can ignore this email.",
+			u.FirstName, s.cfg.Auth.PasswordResetTTL, s.cfg.Server.AppURL, url.QueryEscape(token),
+		),
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Msg("send password reset")
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+func (s service) ResetPassword(ctx context.Context, token, password string) (int, error) {
+	hash := secret.Hash(token)
+
+	id, err := s.repo.FindPasswordReset(ctx, hash)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoResetNotFound) {
+			return 0, ErrInvalidResetToken
+		}
+
+		return 0, ErrInternalService
+	}
+
+	u, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return 0, ErrInvalidResetToken
+		}
+
+		return 0, ErrInternalService
+	}
+
+	// validate before the reset is used up
+	err = s.policy.check(password, u)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err = s.repo.ConsumePasswordReset(ctx, hash)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoResetNotFound) {
+			return 0, ErrInvalidResetToken
+		}
+
+		return 0, ErrInternalService
+	}
+
+	return id, s.setPassword(ctx, id, password)
+}
+
+// setPassword replaces the password of a user and drops pending resets
+func (s service) setPassword(ctx context.Context, id int, password string) error {
+	hash, err := s.Generate(password)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInvalidPassword
+	}
+
+	err = s.repo.UpdatePassword(ctx, id, hash)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return ErrUserNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	err = s.repo.DeletePasswordResets(ctx, id)
+	if err != nil {
+		s.logger.Error().Err(err).Int("user_id", id).Msg("delete password resets")
+	}
+
+	return nil
+}
+
+func (s service) SendEmailVerification(ctx context.Context, id int) error {
+	u, err := s.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if u.EmailVerifiedAt != nil {
+		return ErrEmailVerified
+	}
+
+	err = s.sendEmailVerification(ctx, u)
+	if err != nil {
+		s.logger.Error().Err(err).Int("user_id", u.ID).Msg("send email verification")
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+func (s service) sendEmailVerification(ctx context.Context, u *User) error {
+	token, err := signLink(s.cfg.Auth.LinkSecret, linkVerifyEmail, u, s.cfg.Auth.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, &mail.Message{
+		To:      u.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm this is your email by opening the link below. It expires in %s.\n\n%s/verify-email?token=%s",
+			u.FirstName, s.cfg.Auth.EmailVerificationTTL, s.cfg.Server.AppURL, url.QueryEscape(token),
+		),
+	})
+}
+
+func (s service) VerifyEmail(ctx context.Context, token string) error {
+	claims, err := parseLink(s.cfg.Auth.LinkSecret, linkVerifyEmail, token)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInvalidVerifyToken
+	}
+
+	u, err := s.FindByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return ErrInvalidVerifyToken
+		}
+
+		return err
+	}
+
+	// the link was sent to an address the user no longer has
+	if u.Email != claims.Email {
+		return ErrInvalidVerifyToken
+	}
+
+	if u.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	u.EmailVerifiedAt = &now
+
+	err = s.repo.Update(ctx, u, "email_verified_at")
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// IssueMFAToken returns a short lived token proving u passed the first login
+// step. It is only good for completing the login with a second factor.
+func (s service) IssueMFAToken(u *User) (string, error) {
+	token, err := signLink(s.cfg.Auth.LinkSecret, linkMFAPending, u, s.cfg.Auth.MFATokenTTL)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return "", ErrInternalService
+	}
+
+	return token, nil
+}
+
+// ParseMFAToken returns the user a token of IssueMFAToken was issued to
+func (s service) ParseMFAToken(ctx context.Context, token string) (*User, error) {
+	claims, err := parseLink(s.cfg.Auth.LinkSecret, linkMFAPending, token)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInvalidMFAToken
+	}
+
+	u, err := s.FindByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidMFAToken
+		}
+
+		return nil, err
+	}
+
+	if u.Email != claims.Email {
+		return nil, ErrInvalidMFAToken
+	}
+
+	return u, nil
+}
+
+// SendMagicLink mails a single use login link to email. Like password resets
+// unknown emails are not reported.
+func (s service) SendMagicLink(ctx context.Context, email string) error {
+	if !s.cfg.Auth.MagicLink.Enabled {
+		return ErrMagicLinkDisabled
+	}
+
+	u, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return nil
+		}
+
+		return ErrInternalService
+	}
+
+	token, err := signSingleUseLink(s.cfg.Auth.LinkSecret, linkMagicLogin, u, s.cfg.Auth.MagicLink.TTL)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		return ErrInternalService
+	}
+
+	err = s.mailer.Send(ctx, &mail.Message{
+		To:      u.Email,
+		Subject: "Your login link",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to log in. It expires in %s and works once.\n\n%s/login/magic-link?token=%s\n\nIf you did not ask for it you can ignore this email.",
+			u.FirstName, s.cfg.Auth.MagicLink.TTL, s.cfg.Server.AppURL, url.QueryEscape(token),
+		),
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Int("user_id", u.ID).Msg("send magic link")
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+// RedeemMagicLink returns the user a login link was sent to. The link proves
+// the user owns the email, so it is marked verified as well.
+func (s service) RedeemMagicLink(ctx context.Context, token string) (*User, error) {
+	if !s.cfg.Auth.MagicLink.Enabled {
+		return nil, ErrMagicLinkDisabled
+	}
+
+	claims, err := parseLink(s.cfg.Auth.LinkSecret, linkMagicLogin, token)
+	if err != nil || claims.Nonce == "" {
+		s.logger.Debug().Err(err).Msg("")
+		return nil, ErrInvalidMagicLink
+	}
+
+	u, err := s.FindByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidMagicLink
+		}
+
+		return nil, err
+	}
+
+	if u.Email != claims.Email {
+		return nil, ErrInvalidMagicLink
+	}
+
+	err = s.repo.UseLink(ctx, &UsedLink{
+		Nonce:     claims.Nonce,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	})
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoLinkUsed) {
+			return nil, ErrInvalidMagicLink
+		}
+
+		return nil, ErrInternalService
+	}
+
+	if u.EmailVerifiedAt == nil {
+		now := time.Now()
+		u.EmailVerifiedAt = &now
+
+		err = s.repo.Update(ctx, u, "email_verified_at")
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("")
+			return nil, ErrInternalService
+		}
+	}
+
+	return u, nil
+}
+
+func (s service) AssignRole(ctx context.Context, id int, role string) error {
+	r, err := s.findRole(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.AddRole(ctx, id, r.ID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserNotFound) {
+			return ErrUserNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+func (s service) RemoveRole(ctx context.Context, id int, role string) error {
+	r, err := s.findRole(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.RemoveRole(ctx, id, r.ID)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoUserRoleNotFound) {
+			return ErrUserRoleNotFound
+		}
+
+		return ErrInternalService
+	}
+
+	return nil
+}
+
+func (s service) findRole(ctx context.Context, name string) (*Role, error) {
+	r, err := s.repo.FindRoleByName(ctx, name)
+	if err != nil {
+		s.logger.Debug().Err(err).Msg("")
+		if errors.Is(err, errRepoRoleNotFound) {
+			return nil, ErrRoleNotFound
+		}
+
+		return nil, ErrInternalService
+	}
+
+	return r, nil
+}
+
 // NewService creates a new service
 func NewService(
 	logger zerolog.Logger,
 	repo Repository,
-) Repository {
-	return &service{
+	mailer mail.Mailer,
+	sms sms.SMSSender,
+	breached BreachedPasswords,
+	audit audit.Service,
+	blob storage.Blob,
+	cfg *config.Config,
+) Service {
+	s := &service{
 		logger: logger,
 		repo:   repo,
+		mailer: mailer,
+		sms:    sms,
+		audit:  audit,
+		blob:   blob,
+		cfg:    cfg,
+		policy: newPasswordPolicy(cfg, breached),
 	}
+
+	// the error is ignored on purpose, an empty dummy hash only makes the
+	// unknown email path faster
+	s.dummyHash, _ = s.Generate(pass.NewUUID())
+
+	return s
 }
@@ -1,30 +1,101 @@
 package user
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api-template/internal/config"
 	"go-api-template/internal/openapi"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog"
 )
 
+// Tokens are the credentials handed to a user once authenticated
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int
+}
+
+// Device describes where a user logs in from
+type Device struct {
+	UserAgent string
+	IP        string
+}
+
+// Sessions hands out and takes back the tokens of users
+type Sessions interface {
+	Issue(ctx context.Context, u *User, device Device) (*Tokens, error)
+	RevokeAll(ctx context.Context, userID int) error
+}
+
+// Cookies keeps the tokens of browser clients in cookies
+type Cookies interface {
+	SetTokens(c echo.Context, tokens *Tokens) error
+}
+
+// Throttle slows down credential guessing
+type Throttle interface {
+	Check(ctx context.Context, email, ip string) error
+	Fail(ctx context.Context, email, ip string) error
+	Succeed(ctx context.Context, email string) error
+	CheckSecondFactor(ctx context.Context, userID int, ip string) error
+	FailSecondFactor(ctx context.Context, userID int, ip string) error
+	SucceedSecondFactor(ctx context.Context, userID int) error
+	Allow(ctx context.Context, key string, max int, window time.Duration) error
+}
+
+// SecondFactor verifies the second factor of users who enabled it
+type SecondFactor interface {
+	Enabled(ctx context.Context, userID int) (bool, error)
+	Verify(ctx context.Context, userID int, code string) error
+}
+
+// ErrInvalidCode is returned by a SecondFactor for a wrong code
+var ErrInvalidCode = errors.New("invalid code")
+
+// retryError is an error telling the client to come back later
+type retryError interface {
+	error
+	RetryAfter() time.Duration
+}
+
 // Transport handles transport for service
 type Transport struct {
 	logger        zerolog.Logger
 	srv           Service
-	tokenGenrator func(u *User) (string, error)
+	sessions      Sessions
+	throttle      Throttle
+	mfa           SecondFactor
+	cookies       Cookies
+	currentUserID func(c echo.Context) int
+	cfg           *config.Config
 }
 
 // NewTransport creates a new transport
 func NewTransport(
 	logger zerolog.Logger,
 	srv Service,
-	tokenGenrator func(u *User) (string, error),
+	sessions Sessions,
+	throttle Throttle,
+	mfa SecondFactor,
+	cookies Cookies,
+	currentUserID func(c echo.Context) int,
+	cfg *config.Config,
 ) Transport {
 	return Transport{
 		logger:        logger,
 		srv:           srv,
-		tokenGenrator: tokenGenrator,
+		sessions:      sessions,
+		throttle:      throttle,
+		mfa:           mfa,
+		cookies:       cookies,
+		currentUserID: currentUserID,
+		cfg:           cfg,
 	}
 }
 
@@ -50,6 +121,14 @@ func (h Transport) RegisterUser(c echo.Context) error {
 	})
 	if err != nil {
 		h.logger.Err(err).Msg("")
+		var verr *ValidationError
+		switch {
+		case errors.As(err, &verr):
+			return validationError(verr, nil)
+		case errors.Is(err, ErrUserAlreadyExists):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
@@ -69,20 +148,883 @@ func (h Transport) LoginUser(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	u, err := h.srv.FindByEmail(ctx, req.Email)
+	err = h.throttle.Check(ctx, req.Email, c.RealIP())
+	if err != nil {
+		return h.throttleError(err)
+	}
+
+	u, err := h.srv.Authenticate(ctx, req.Email, req.Password)
 	if err != nil {
 		h.logger.Err(err).Msg("")
-		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		if errors.Is(err, ErrInvalidCredentials) {
+			ferr := h.throttle.Fail(ctx, req.Email, c.RealIP())
+			if ferr != nil {
+				return h.throttleError(ferr)
+			}
+
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	err = h.throttle.Succeed(ctx, req.Email)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+	}
+
+	return h.authenticated(c, u)
+}
+
+// authenticated continues the login of a user who proved the first factor
+func (h Transport) authenticated(c echo.Context, u *User) error {
+	ctx := c.Request().Context()
+
+	if !u.Active {
+		return echo.NewHTTPError(http.StatusForbidden, ErrUserInactive.Error())
+	}
+
+	if h.cfg.Auth.VerifyEmail && u.EmailVerifiedAt == nil {
+		return echo.NewHTTPError(http.StatusForbidden, ErrEmailNotVerified.Error())
+	}
+
+	enabled, err := h.mfa.Enabled(ctx, u.ID)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	if enabled {
+		mfaToken, err := h.srv.IssueMFAToken(u)
+		if err != nil {
+			h.logger.Err(err).Msg("")
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, openapi.UserLoginResponse{
+			MfaRequired: true,
+			MfaToken:    &mfaToken,
+			FirstName:   u.FirstName,
+			LastName:    u.LastName,
+		})
+	}
+
+	return h.login(c, u)
+}
+
+// SendMagicLink mails a login link. The response is the same whether or not
+// an account exists for the email.
+func (h Transport) SendMagicLink(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.MagicLinkRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ml := h.cfg.Auth.MagicLink
+	if !ml.Enabled {
+		return echo.NewHTTPError(http.StatusNotFound, ErrMagicLinkDisabled.Error())
+	}
+
+	err = h.throttle.Allow(ctx, "magic-link:"+strings.ToLower(strings.TrimSpace(req.Email)), ml.MaxRequests, ml.Window)
+	if err != nil {
+		return h.throttleError(err)
+	}
+
+	err = h.srv.SendMagicLink(ctx, req.Email)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "if an account exists for this email a login link has been sent",
+	})
+}
+
+// RedeemMagicLink logs in the user a login link was sent to
+func (h Transport) RedeemMagicLink(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.MagicLinkRedeemRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	u, err := h.srv.RedeemMagicLink(ctx, req.Token)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		switch {
+		case errors.Is(err, ErrMagicLinkDisabled):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrInvalidMagicLink):
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return h.authenticated(c, u)
+}
+
+// LoginUserMfa completes a login with a TOTP or recovery code
+func (h Transport) LoginUserMfa(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.MfaLoginRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	u, err := h.srv.ParseMFAToken(ctx, req.MfaToken)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrInvalidMFAToken) {
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// codes are short, guessing them is throttled like passwords but on a key
+	// of its own, logging in with the password again must not reset it
+	err = h.throttle.CheckSecondFactor(ctx, u.ID, c.RealIP())
+	if err != nil {
+		return h.throttleError(err)
+	}
+
+	err = h.mfa.Verify(ctx, u.ID, req.Code)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrInvalidCode) {
+			ferr := h.throttle.FailSecondFactor(ctx, u.ID, c.RealIP())
+			if ferr != nil {
+				return h.throttleError(ferr)
+			}
+
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	err = h.throttle.SucceedSecondFactor(ctx, u.ID)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+	}
+
+	return h.login(c, u)
+}
+
+// login issues a token pair to an authenticated user
+func (h Transport) login(c echo.Context, u *User) error {
+	if !u.Active {
+		return echo.NewHTTPError(http.StatusForbidden, ErrUserInactive.Error())
+	}
+
+	tokens, err := h.sessions.Issue(c.Request().Context(), u, Device{
+		UserAgent: c.Request().UserAgent(),
+		IP:        c.RealIP(),
+	})
+	if err != nil {
+		return err
 	}
 
-	token, err := h.tokenGenrator(u)
+	err = h.cookies.SetTokens(c, tokens)
 	if err != nil {
 		return err
 	}
 
 	return c.JSON(http.StatusOK, openapi.UserLoginResponse{
-		Token:     token,
-		FirstName: u.FirstName,
-		LastName:  u.LastName,
+		Token:        &tokens.AccessToken,
+		RefreshToken: &tokens.RefreshToken,
+		ExpiresIn:    &tokens.ExpiresIn,
+		FirstName:    u.FirstName,
+		LastName:     u.LastName,
+	})
+}
+
+// newPasswordField names the password field of requests changing a password
+var newPasswordField = map[string]string{"password": "new_password"}
+
+// validationError reports the rules a request broke. fields maps the fields
+// of the service to the ones of the request where their names differ.
+func validationError(verr *ValidationError, fields map[string]string) error {
+	res := openapi.ValidationError{
+		Message: "validation failed",
+		Fields:  make([]openapi.FieldError, 0, len(verr.Fields)),
+	}
+	for _, f := range verr.Fields {
+		field := f.Field
+		if name, ok := fields[field]; ok {
+			field = name
+		}
+
+		res.Fields = append(res.Fields, openapi.FieldError{
+			Field:   field,
+			Code:    f.Code,
+			Message: f.Message,
+		})
+	}
+
+	return echo.NewHTTPError(http.StatusBadRequest, res).SetInternal(verr)
+}
+
+// throttleError maps an error of the throttle to a response, 429 with the
+// time to wait when locked
+func (h Transport) throttleError(err error) error {
+	h.logger.Err(err).Msg("")
+
+	var retry retryError
+	if errors.As(err, &retry) {
+		return echo.NewHTTPError(http.StatusTooManyRequests, err.Error()).SetInternal(err)
+	}
+
+	return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+}
+
+// AssignUserRole grants a role to a user
+func (h Transport) AssignUserRole(c echo.Context, id int, role string) error {
+	ctx := c.Request().Context()
+
+	err := h.srv.AssignRole(ctx, id, role)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrUserNotFound) || errors.Is(err, ErrRoleNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "role assigned",
+	})
+}
+
+// RemoveUserRole takes a role away from a user and logs out every session of
+// theirs, so no token keeps the permissions of the role
+func (h Transport) RemoveUserRole(c echo.Context, id int, role string) error {
+	ctx := c.Request().Context()
+
+	err := h.srv.RemoveRole(ctx, id, role)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrRoleNotFound) || errors.Is(err, ErrUserRoleNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	err = h.sessions.RevokeAll(ctx, id)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "role removed",
+	})
+}
+
+// GetMe returns the profile of the current user
+func (h Transport) GetMe(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	u, err := h.srv.FindByID(ctx, h.currentUserID(c))
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, h.profile(u))
+}
+
+// UpdateMe updates the profile of the current user
+func (h Transport) UpdateMe(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.UserUpdateRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	u, err := h.srv.Update(ctx, h.currentUserID(c), &Update{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Address:   req.Address,
+	})
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, h.profile(u))
+}
+
+// UploadAvatar replaces the avatar of the current user
+func (h Transport) UploadAvatar(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if fh.Size > h.cfg.Avatar.MaxSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, ErrAvatarTooLarge.Error())
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	defer f.Close()
+
+	u, err := h.srv.SetAvatar(ctx, h.currentUserID(c), f)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		switch {
+		case errors.Is(err, ErrAvatarTooLarge):
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err.Error())
+		case errors.Is(err, ErrAvatarType):
+			return echo.NewHTTPError(http.StatusUnsupportedMediaType, err.Error())
+		case errors.Is(err, ErrUserNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, h.profile(u))
+}
+
+// DeleteAvatar removes the avatar of the current user
+func (h Transport) DeleteAvatar(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	u, err := h.srv.DeleteAvatar(ctx, h.currentUserID(c))
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, h.profile(u))
+}
+
+// DeleteMe deletes the account of the current user
+func (h Transport) DeleteMe(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.AccountDeleteRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	id := h.currentUserID(c)
+
+	err = h.srv.Delete(ctx, id, req.Password, c.RealIP())
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrIncorrectPassword) {
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	err = h.sessions.RevokeAll(ctx, id)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "account deleted",
+	})
+}
+
+// ChangeEmail sends a confirmation code to a new email of the current user
+func (h Transport) ChangeEmail(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.EmailChangeRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	id := h.currentUserID(c)
+
+	err = h.allowContactChange(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = h.srv.RequestEmailChange(ctx, id, req.Password, req.Email)
+	if err != nil {
+		return h.contactChangeError(err)
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "a confirmation code has been sent to the new email",
+	})
+}
+
+// ConfirmEmailChange replaces the email of the current user with the pending
+// one
+func (h Transport) ConfirmEmailChange(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.ContactChangeConfirmRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	u, err := h.srv.ConfirmEmailChange(ctx, h.currentUserID(c), req.Code, c.RealIP())
+	if err != nil {
+		return h.contactChangeError(err)
+	}
+
+	return c.JSON(http.StatusOK, h.profile(u))
+}
+
+// ChangeMobile texts a confirmation code to a new mobile of the current user
+func (h Transport) ChangeMobile(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.MobileChangeRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	id := h.currentUserID(c)
+
+	err = h.allowContactChange(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = h.srv.RequestMobileChange(ctx, id, req.Password, req.Mobile)
+	if err != nil {
+		return h.contactChangeError(err)
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "a confirmation code has been sent to the new mobile number",
+	})
+}
+
+// ConfirmMobileChange replaces the mobile of the current user with the
+// pending one
+func (h Transport) ConfirmMobileChange(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.ContactChangeConfirmRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	u, err := h.srv.ConfirmMobileChange(ctx, h.currentUserID(c), req.Code, c.RealIP())
+	if err != nil {
+		return h.contactChangeError(err)
+	}
+
+	return c.JSON(http.StatusOK, h.profile(u))
+}
+
+// allowContactChange limits how many codes a user can have sent, every one
+// of them reaches an address the user does not own yet
+func (h Transport) allowContactChange(ctx context.Context, id int) error {
+	cc := h.cfg.Auth.ContactChange
+
+	err := h.throttle.Allow(ctx, fmt.Sprintf("contact-change:%d", id), cc.MaxRequests, cc.Window)
+	if err != nil {
+		return h.throttleError(err)
+	}
+
+	return nil
+}
+
+func (h Transport) contactChangeError(err error) error {
+	h.logger.Err(err).Msg("")
+
+	switch {
+	case errors.Is(err, ErrIncorrectPassword):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case errors.Is(err, ErrContactInUse):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, ErrInvalidEmail),
+		errors.Is(err, ErrInvalidMobile),
+		errors.Is(err, ErrContactUnchanged),
+		errors.Is(err, ErrInvalidChangeCode):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrUserNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+}
+
+func (h Transport) profile(u *User) openapi.UserProfile {
+	thumbnails := []openapi.Thumbnail{}
+	for _, t := range h.srv.Thumbnails(u) {
+		thumbnails = append(thumbnails, openapi.Thumbnail{
+			Size: t.Size,
+			Url:  t.URL,
+		})
+	}
+
+	return openapi.UserProfile{
+		Email:         u.Email,
+		Mobile:        u.Mobile,
+		FirstName:     u.FirstName,
+		LastName:      u.LastName,
+		Address:       u.Address,
+		ImageUrl:      h.srv.ImageURL(u),
+		Thumbnails:    thumbnails,
+		EmailVerified: u.EmailVerifiedAt != nil,
+		Roles:         u.RoleNames(),
+	}
+}
+
+// ListUsers returns a page of the users matching the filters
+func (h Transport) ListUsers(c echo.Context, params openapi.ListUsersParams) error {
+	ctx := c.Request().Context()
+
+	f := &ListFilter{
+		Active:        params.Active,
+		CreatedAfter:  params.CreatedAfter,
+		CreatedBefore: params.CreatedBefore,
+	}
+	if params.Email != nil {
+		f.EmailPrefix = *params.Email
+	}
+	if params.Name != nil {
+		f.NamePrefix = *params.Name
+	}
+	if params.IncludeDeleted != nil {
+		f.WithDeleted = *params.IncludeDeleted
+	}
+	if params.Sort != nil {
+		f.Sort = *params.Sort
+	}
+	if params.Order != nil {
+		f.Desc = *params.Order == "desc"
+	}
+	if params.Limit != nil {
+		f.Limit = *params.Limit
+	}
+
+	var cursor string
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	users, next, err := h.srv.List(ctx, f, cursor)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrInvalidCursor) || errors.Is(err, ErrInvalidSort) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	res := openapi.UserList{
+		Users: make([]openapi.AdminUser, 0, len(users)),
+	}
+	if next != "" {
+		res.NextCursor = &next
+	}
+	for i := range users {
+		u := &users[i]
+		res.Users = append(res.Users, openapi.AdminUser{
+			Id:            u.ID,
+			Email:         u.Email,
+			Mobile:        u.Mobile,
+			FirstName:     u.FirstName,
+			LastName:      u.LastName,
+			Active:        u.Active,
+			EmailVerified: u.EmailVerifiedAt != nil,
+			Roles:         u.RoleNames(),
+			CreatedAt:     u.CreatedAt,
+			DeletedAt:     u.DeletedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// SuspendUser suspends a user and logs out every session of theirs
+func (h Transport) SuspendUser(c echo.Context, id int) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.AdminActionRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	err = h.srv.Suspend(ctx, h.currentUserID(c), id, req.Reason, c.RealIP())
+	if err != nil {
+		return h.adminActionError(err)
+	}
+
+	err = h.sessions.RevokeAll(ctx, id)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "user suspended",
+	})
+}
+
+// ReactivateUser lifts the suspension of a user
+func (h Transport) ReactivateUser(c echo.Context, id int) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.AdminActionRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	err = h.srv.Reactivate(ctx, h.currentUserID(c), id, req.Reason, c.RealIP())
+	if err != nil {
+		return h.adminActionError(err)
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "user reactivated",
+	})
+}
+
+// RestoreUser brings back a deleted user within the restore window
+func (h Transport) RestoreUser(c echo.Context, id int) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.AdminActionRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	err = h.srv.Restore(ctx, h.currentUserID(c), id, req.Reason, c.RealIP())
+	if err != nil {
+		return h.adminActionError(err)
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "user restored",
+	})
+}
+
+// adminActionError maps the errors of suspending, reactivating and restoring
+// users to responses
+func (h Transport) adminActionError(err error) error {
+	h.logger.Err(err).Msg("")
+	switch {
+	case errors.Is(err, ErrReasonRequired), errors.Is(err, ErrSuspendSelf):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrUserNotFound), errors.Is(err, ErrNotRestorable):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+}
+
+// ChangePassword changes the password of the current user
+func (h Transport) ChangePassword(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.PasswordChangeRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	id := h.currentUserID(c)
+
+	err = h.srv.ChangePassword(ctx, id, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		var verr *ValidationError
+		switch {
+		case errors.Is(err, ErrIncorrectPassword):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case errors.As(err, &verr):
+			return validationError(verr, newPasswordField)
+		case errors.Is(err, ErrInvalidPassword):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	err = h.sessions.RevokeAll(ctx, id)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "password changed",
+	})
+}
+
+// ForgotPassword mails a password reset link
+func (h Transport) ForgotPassword(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.PasswordForgotRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	// every request sends a mail, neither an inbox nor the table of reset
+	// tokens may be flooded
+	pr := h.cfg.Auth.PasswordReset
+
+	err = h.throttle.Allow(ctx, "password-reset:"+strings.ToLower(strings.TrimSpace(req.Email)), pr.MaxRequests, pr.Window)
+	if err != nil {
+		return h.throttleError(err)
+	}
+
+	err = h.throttle.Allow(ctx, "password-reset-ip:"+c.RealIP(), pr.MaxIPRequests, pr.Window)
+	if err != nil {
+		return h.throttleError(err)
+	}
+
+	err = h.srv.RequestPasswordReset(ctx, req.Email)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "if the email belongs to an account a reset link was sent to it",
+	})
+}
+
+// ResetPassword sets a new password with a reset token
+func (h Transport) ResetPassword(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.PasswordResetRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	id, err := h.srv.ResetPassword(ctx, req.Token, req.NewPassword)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		var verr *ValidationError
+		if errors.As(err, &verr) {
+			return validationError(verr, newPasswordField)
+		}
+		if errors.Is(err, ErrInvalidResetToken) || errors.Is(err, ErrInvalidPassword) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	err = h.sessions.RevokeAll(ctx, id)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "password reset",
+	})
+}
+
+// VerifyEmail confirms the email of a user with the token mailed to it
+func (h Transport) VerifyEmail(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := &openapi.EmailVerifyRequest{}
+	err := c.Bind(req)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	err = h.srv.VerifyEmail(ctx, req.Token)
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrInvalidVerifyToken) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "email verified",
+	})
+}
+
+// ResendEmailVerification mails another verification link to the current user
+func (h Transport) ResendEmailVerification(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.srv.SendEmailVerification(ctx, h.currentUserID(c))
+	if err != nil {
+		h.logger.Err(err).Msg("")
+		if errors.Is(err, ErrEmailVerified) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, openapi.Status{
+		Message: "verification email sent",
 	})
 }
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "refresh_tokens" (
+				"id" bigserial,
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"family_id" text NOT NULL,
+				"token_hash" text NOT NULL UNIQUE,
+				"expires_at" timestamptz NOT NULL,
+				"used_at" timestamptz,
+				"revoked_at" timestamptz,
+				"created_at" timestamptz NOT NULL,
+				PRIMARY KEY ("id")
+			);
+			CREATE INDEX "refresh_tokens_family_id_idx" ON "refresh_tokens" ("family_id");
+			CREATE INDEX "refresh_tokens_user_id_idx" ON "refresh_tokens" ("user_id");
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "refresh_tokens";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20201221093512_refresh_tokens", up, down, opts)
+}
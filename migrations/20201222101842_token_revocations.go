@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "revoked_tokens" (
+				"jti" text NOT NULL,
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"expires_at" timestamptz NOT NULL,
+				"created_at" timestamptz NOT NULL,
+				PRIMARY KEY ("jti")
+			);
+			CREATE TABLE "user_revocations" (
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"revoked_before" timestamptz NOT NULL,
+				PRIMARY KEY ("user_id")
+			);
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "user_revocations";
+			DROP TABLE "revoked_tokens";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20201222101842_token_revocations", up, down, opts)
+}
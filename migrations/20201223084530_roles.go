@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "roles" (
+				"id" bigserial,
+				"name" text NOT NULL UNIQUE,
+				"permissions" text[] NOT NULL DEFAULT '{}',
+				"created_at" timestamptz NOT NULL DEFAULT now(),
+				PRIMARY KEY ("id")
+			);
+			CREATE TABLE "user_roles" (
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"role_id" bigint NOT NULL REFERENCES "roles" ("id") ON DELETE CASCADE,
+				PRIMARY KEY ("user_id", "role_id")
+			);
+			INSERT INTO "roles" ("name", "permissions") VALUES ('admin', '{admin}');
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "user_roles";
+			DROP TABLE "roles";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20201223084530_roles", up, down, opts)
+}
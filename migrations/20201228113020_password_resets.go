@@ -0,0 +1,34 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "password_resets" (
+				"id" bigserial,
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"token_hash" text NOT NULL UNIQUE,
+				"expires_at" timestamptz NOT NULL,
+				"created_at" timestamptz NOT NULL,
+				PRIMARY KEY ("id")
+			);
+			CREATE INDEX "password_resets_user_id_idx" ON "password_resets" ("user_id");
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "password_resets";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20201228113020_password_resets", up, down, opts)
+}
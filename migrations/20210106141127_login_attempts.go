@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "login_attempts" (
+				"key" text NOT NULL,
+				"failures" integer NOT NULL,
+				"locked_until" timestamptz,
+				"updated_at" timestamptz NOT NULL,
+				PRIMARY KEY ("key")
+			);
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "login_attempts";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20210106141127_login_attempts", up, down, opts)
+}
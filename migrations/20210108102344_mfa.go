@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "mfa_secrets" (
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"secret" text NOT NULL,
+				"last_counter" bigint NOT NULL DEFAULT 0,
+				"confirmed_at" timestamptz,
+				"created_at" timestamptz NOT NULL,
+				PRIMARY KEY ("user_id")
+			);
+			CREATE TABLE "mfa_recovery_codes" (
+				"id" bigserial,
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"code_hash" text NOT NULL,
+				"used_at" timestamptz,
+				"created_at" timestamptz NOT NULL,
+				PRIMARY KEY ("id"),
+				UNIQUE ("user_id", "code_hash")
+			);
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "mfa_recovery_codes";
+			DROP TABLE "mfa_secrets";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20210108102344_mfa", up, down, opts)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "used_links" (
+				"nonce" text NOT NULL,
+				"expires_at" timestamptz NOT NULL,
+				"created_at" timestamptz NOT NULL,
+				PRIMARY KEY ("nonce")
+			);
+			CREATE INDEX "used_links_expires_at_idx" ON "used_links" ("expires_at");
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "used_links";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20210111093051_used_links", up, down, opts)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "api_keys" (
+				"id" bigserial,
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"name" text NOT NULL,
+				"prefix" text NOT NULL UNIQUE,
+				"secret_hash" text NOT NULL,
+				"scopes" text[] NOT NULL DEFAULT '{}',
+				"expires_at" timestamptz,
+				"last_used_at" timestamptz,
+				"created_at" timestamptz NOT NULL,
+				"updated_at" timestamptz NOT NULL,
+				PRIMARY KEY ("id")
+			);
+			CREATE INDEX "api_keys_user_id_idx" ON "api_keys" ("user_id");
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "api_keys";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20210113154210_api_keys", up, down, opts)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "oauth_clients" (
+				"id" bigserial,
+				"client_id" text NOT NULL UNIQUE,
+				"secret_hash" text NOT NULL DEFAULT '',
+				"name" text NOT NULL,
+				"redirect_uris" text[] NOT NULL DEFAULT '{}',
+				"scopes" text[] NOT NULL DEFAULT '{}',
+				"created_at" timestamptz NOT NULL,
+				"updated_at" timestamptz NOT NULL,
+				PRIMARY KEY ("id")
+			);
+			CREATE TABLE "oauth_authorization_codes" (
+				"code_hash" text NOT NULL,
+				"client_id" text NOT NULL REFERENCES "oauth_clients" ("client_id") ON DELETE CASCADE,
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"redirect_uri" text NOT NULL,
+				"scopes" text[] NOT NULL DEFAULT '{}',
+				"code_challenge" text NOT NULL,
+				"expires_at" timestamptz NOT NULL,
+				"created_at" timestamptz NOT NULL,
+				PRIMARY KEY ("code_hash")
+			);
+			CREATE TABLE "oauth_consents" (
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"client_id" text NOT NULL REFERENCES "oauth_clients" ("client_id") ON DELETE CASCADE,
+				"scopes" text[] NOT NULL DEFAULT '{}',
+				"created_at" timestamptz NOT NULL,
+				"updated_at" timestamptz NOT NULL,
+				PRIMARY KEY ("user_id", "client_id")
+			);
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "oauth_consents";
+			DROP TABLE "oauth_authorization_codes";
+			DROP TABLE "oauth_clients";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20210118110402_oauth", up, down, opts)
+}
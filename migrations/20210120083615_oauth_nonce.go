@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			ALTER TABLE "oauth_authorization_codes" ADD COLUMN "nonce" text NOT NULL DEFAULT '';
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			ALTER TABLE "oauth_authorization_codes" DROP COLUMN "nonce";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20210120083615_oauth_nonce", up, down, opts)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "sessions" (
+				"id" text,
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"jti" text NOT NULL DEFAULT '',
+				"user_agent" text NOT NULL DEFAULT '',
+				"ip" text NOT NULL DEFAULT '',
+				"created_at" timestamptz NOT NULL,
+				"last_seen_at" timestamptz NOT NULL,
+				PRIMARY KEY ("id")
+			);
+			CREATE INDEX "sessions_user_id_idx" ON "sessions" ("user_id");
+
+			-- logins made before sessions existed keep working
+			INSERT INTO "sessions" ("id", "user_id", "created_at", "last_seen_at")
+			SELECT "family_id", "user_id", min("created_at"), max("created_at")
+			FROM "refresh_tokens"
+			WHERE "revoked_at" IS NULL AND "expires_at" > now()
+			GROUP BY "family_id", "user_id";
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "sessions";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20210122094530_sessions", up, down, opts)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "audit_log" (
+				"id" bigserial,
+				"action" text NOT NULL,
+				"actor_id" bigint NOT NULL,
+				"user_id" bigint NOT NULL,
+				"ip" text NOT NULL DEFAULT '',
+				"details" jsonb NOT NULL DEFAULT '{}',
+				"created_at" timestamptz NOT NULL,
+				PRIMARY KEY ("id")
+			);
+			CREATE INDEX "audit_log_user_id_idx" ON "audit_log" ("user_id");
+			CREATE INDEX "audit_log_actor_id_idx" ON "audit_log" ("actor_id");
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "audit_log";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20210125101204_audit_log", up, down, opts)
+}
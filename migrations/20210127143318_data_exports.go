@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "data_exports" (
+				"id" text,
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"format" text NOT NULL,
+				"status" text NOT NULL,
+				"archive" bytea,
+				"started_at" timestamptz,
+				"completed_at" timestamptz,
+				"expires_at" timestamptz,
+				"created_at" timestamptz NOT NULL,
+				PRIMARY KEY ("id")
+			);
+			CREATE INDEX "data_exports_user_id_idx" ON "data_exports" ("user_id");
+			CREATE INDEX "data_exports_status_idx" ON "data_exports" ("status", "created_at");
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "data_exports";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20210127143318_data_exports", up, down, opts)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		// image urls were whatever clients sent, avatars are now uploaded and
+		// their urls derived from where they are stored
+		_, err := db.Exec(`
+			ALTER TABLE "users" ADD COLUMN "avatar_key" text NOT NULL DEFAULT '';
+			ALTER TABLE "users" DROP COLUMN "image_url";
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			ALTER TABLE "users" ADD COLUMN "image_url" text NOT NULL DEFAULT '';
+			ALTER TABLE "users" DROP COLUMN "avatar_key";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20210129091247_avatars", up, down, opts)
+}
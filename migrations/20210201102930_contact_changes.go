@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/go-pg/pg/v10/orm"
+	migrations "github.com/robinjoseph08/go-pg-migrations/v3"
+)
+
+func init() {
+	up := func(db orm.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE "contact_changes" (
+				"id" bigserial,
+				"user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+				"kind" text NOT NULL,
+				"value" text NOT NULL,
+				"code_hash" text NOT NULL,
+				"attempts" int NOT NULL DEFAULT 0,
+				"expires_at" timestamptz NOT NULL,
+				"created_at" timestamptz NOT NULL,
+				PRIMARY KEY ("id"),
+				UNIQUE ("user_id", "kind")
+			);
+		`)
+		return err
+	}
+
+	down := func(db orm.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE "contact_changes";
+		`)
+		return err
+	}
+
+	opts := migrations.MigrationOptions{}
+
+	migrations.Register("20210201102930_contact_changes", up, down, opts)
+}
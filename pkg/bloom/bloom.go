@@ -0,0 +1,141 @@
+package bloom
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// magic starts every filter file, the last byte is the format version
+var magic = [4]byte{'B', 'L', 'M', 1}
+
+// ErrInvalidFile is returned when reading something that is not a filter
+var ErrInvalidFile = errors.New("invalid bloom filter file")
+
+// Filter is a bloom filter. Test never misses a value that was added and
+// reports a value that was not with the false positive rate it was sized for.
+type Filter struct {
+	k    uint32
+	m    uint64
+	bits []byte
+}
+
+// New returns a filter sized for n values at false positive rate p
+func New(n uint64, p float64) *Filter {
+	if n == 0 {
+		n = 1
+	}
+
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k := uint32(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k == 0 {
+		k = 1
+	}
+
+	// whole bytes so the file holds exactly the bits
+	m = (m + 7) / 8 * 8
+
+	return &Filter{
+		k:    k,
+		m:    m,
+		bits: make([]byte, m/8),
+	}
+}
+
+// Add adds a value to the filter
+func (f *Filter) Add(value []byte) {
+	h1, h2 := hashes(value)
+	for i := uint64(0); i < uint64(f.k); i++ {
+		bit := (h1 + i*h2) % f.m
+		f.bits[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Test reports whether value may have been added to the filter
+func (f *Filter) Test(value []byte) bool {
+	h1, h2 := hashes(value)
+	for i := uint64(0); i < uint64(f.k); i++ {
+		bit := (h1 + i*h2) % f.m
+		if f.bits[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WriteTo writes the filter in its file format
+func (f *Filter) WriteTo(w io.Writer) (int64, error) {
+	header := make([]byte, 16)
+	copy(header, magic[:])
+	binary.BigEndian.PutUint32(header[4:], f.k)
+	binary.BigEndian.PutUint64(header[8:], f.m)
+
+	n, err := w.Write(header)
+	if err != nil {
+		return int64(n), err
+	}
+
+	nb, err := w.Write(f.bits)
+
+	return int64(n + nb), err
+}
+
+// Read reads a filter written by WriteTo
+func Read(r io.Reader) (*Filter, error) {
+	header := make([]byte, 16)
+	_, err := io.ReadFull(r, header)
+	if err != nil {
+		return nil, ErrInvalidFile
+	}
+
+	if string(header[:4]) != string(magic[:]) {
+		return nil, ErrInvalidFile
+	}
+
+	f := &Filter{
+		k: binary.BigEndian.Uint32(header[4:]),
+		m: binary.BigEndian.Uint64(header[8:]),
+	}
+	if f.k == 0 || f.m == 0 || f.m%8 != 0 {
+		return nil, ErrInvalidFile
+	}
+
+	f.bits = make([]byte, f.m/8)
+	_, err = io.ReadFull(r, f.bits)
+	if err != nil {
+		return nil, ErrInvalidFile
+	}
+
+	return f, nil
+}
+
+// Open reads the filter file at path
+func Open(path string) (*Filter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open bloom filter %s: %v", path, err)
+	}
+	defer file.Close()
+
+	f, err := Read(bufio.NewReader(file))
+	if err != nil {
+		return nil, fmt.Errorf("read bloom filter %s: %v", path, err)
+	}
+
+	return f, nil
+}
+
+// hashes returns the two hashes the bit positions of a value are derived from
+// (Kirsch and Mitzenmacher double hashing)
+func hashes(value []byte) (uint64, uint64) {
+	sum := sha256.Sum256(value)
+
+	// a zero step would put every position on the same bit
+	return binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:16]) | 1
+}
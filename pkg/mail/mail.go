@@ -0,0 +1,94 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"go-api-template/internal/config"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Message is an email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails
+type Mailer interface {
+	Send(ctx context.Context, m *Message) error
+}
+
+// logMailer writes emails to the log instead of sending them
+type logMailer struct {
+	logger zerolog.Logger
+	from   string
+}
+
+func (m logMailer) Send(ctx context.Context, msg *Message) error {
+	m.logger.Info().
+		Str("from", m.from).
+		Str("to", msg.To).
+		Str("subject", msg.Subject).
+		Str("body", msg.Body).
+		Msg("mail")
+
+	return nil
+}
+
+// fileMailer writes every email to its own file in dir
+type fileMailer struct {
+	logger zerolog.Logger
+	from   string
+	dir    string
+}
+
+func (m fileMailer) Send(ctx context.Context, msg *Message) error {
+	err := os.MkdirAll(m.dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "From: %s\r\n", m.from)
+	fmt.Fprintf(b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(b, "\r\n%s\r\n", msg.Body)
+
+	name := filepath.Join(m.dir, fmt.Sprintf("%d.eml", time.Now().UnixNano()))
+
+	err = ioutil.WriteFile(name, []byte(b.String()), 0600)
+	if err != nil {
+		return err
+	}
+
+	m.logger.Debug().Str("to", msg.To).Str("file", name).Msg("mail written")
+
+	return nil
+}
+
+// New returns the mailer selected in config
+func New(logger zerolog.Logger, cfg *config.Config) (Mailer, error) {
+	switch cfg.Mail.Driver {
+	case "", "log":
+		return &logMailer{
+			logger: logger,
+			from:   cfg.Mail.From,
+		}, nil
+	case "file":
+		return &fileMailer{
+			logger: logger,
+			from:   cfg.Mail.From,
+			dir:    cfg.Mail.Dir,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown mail driver %s", cfg.Mail.Driver)
+}
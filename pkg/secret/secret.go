@@ -0,0 +1,113 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// Errors returned when a token or a ciphertext does not match the key
+var (
+	ErrInvalidSignature  = errors.New("invalid signature")
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+// DefaultSize is the amount of randomness in a token
+const DefaultSize = 32
+
+// Token returns a url safe random token of size random bytes
+func Token(size int) (string, error) {
+	b := make([]byte, size)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Hash returns the hex encoded sha256 of a token. Tokens are random so a
+// plain hash is enough to store them without keeping them usable.
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Sign returns payload encoded along with its HMAC-SHA256 under key
+func Sign(key []byte, payload []byte) string {
+	p := base64.RawURLEncoding.EncodeToString(payload)
+	return p + "." + mac(key, p)
+}
+
+// Verify checks a token created by Sign and returns its payload
+func Verify(key []byte, token string) ([]byte, error) {
+	i := strings.LastIndexByte(token, '.')
+	if i < 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(mac(key, token[:i])), []byte(token[i+1:])) {
+		return nil, ErrInvalidSignature
+	}
+
+	return base64.RawURLEncoding.DecodeString(token[:i])
+}
+
+func mac(key []byte, s string) string {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(s))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// Encrypt seals plaintext with AES-256-GCM under a key derived from key
+func Encrypt(key []byte, plaintext []byte) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(aead.Seal(nonce, nonce, plaintext, nil)), nil
+}
+
+// Decrypt opens a ciphertext created by Encrypt
+func Decrypt(key []byte, ciphertext string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(ciphertext)
+	if err != nil || len(b) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	plaintext, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	k := sha256.Sum256(key)
+
+	block, err := aes.NewCipher(k[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,49 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"go-api-template/internal/config"
+
+	"github.com/rs/zerolog"
+)
+
+// Message is a text message
+type Message struct {
+	To   string
+	Body string
+}
+
+// SMSSender sends text messages
+type SMSSender interface {
+	Send(ctx context.Context, m *Message) error
+}
+
+// logSender writes text messages to the log instead of sending them
+type logSender struct {
+	logger zerolog.Logger
+	from   string
+}
+
+func (s logSender) Send(ctx context.Context, msg *Message) error {
+	s.logger.Info().
+		Str("from", s.from).
+		Str("to", msg.To).
+		Str("body", msg.Body).
+		Msg("sms")
+
+	return nil
+}
+
+// New returns the sender selected in config
+func New(logger zerolog.Logger, cfg *config.Config) (SMSSender, error) {
+	switch cfg.SMS.Driver {
+	case "", "log":
+		return &logSender{
+			logger: logger,
+			from:   cfg.SMS.From,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown sms driver %s", cfg.SMS.Driver)
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api-template/internal/config"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// Errors returned by blob stores
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Blob stores objects under slash separated keys and tells where the public
+// can fetch them
+type Blob interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes an object, a missing one is not an error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// validKey reports whether key is relative, clean and stays below the root
+func validKey(key string) bool {
+	return key != "" &&
+		path.Clean(key) == key &&
+		!strings.HasPrefix(key, "/") &&
+		!strings.HasPrefix(key, "../") &&
+		key != ".."
+}
+
+// New returns the blob store selected in config
+func New(logger zerolog.Logger, cfg *config.Config) (Blob, error) {
+	switch cfg.Storage.Driver {
+	case "", "fs":
+		return &fsBlob{
+			logger:  logger,
+			dir:     cfg.Storage.Dir,
+			baseURL: strings.TrimSuffix(cfg.Storage.BaseURL, "/"),
+		}, nil
+	case "s3":
+		return newS3Blob(logger, cfg)
+	}
+
+	return nil, fmt.Errorf("unknown storage driver %s", cfg.Storage.Driver)
+}